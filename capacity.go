@@ -0,0 +1,71 @@
+package rid
+
+import "math"
+
+// Layout describes the random space of an ID variant, for capacity planning.
+type Layout struct {
+	RandomBits     int // random bits per timestamp tick
+	TicksPerSecond int // timestamp resolution
+}
+
+var (
+	// LayoutID is the layout of ID: 48 random bits per second.
+	LayoutID = Layout{RandomBits: 48, TicksPerSecond: 1}
+
+	// LayoutMilli is the layout of MilliID: 32 random bits per millisecond.
+	LayoutMilli = Layout{RandomBits: 32, TicksPerSecond: 1000}
+)
+
+// CollisionProbability returns the probability of at least one duplicate ID
+// among IDs generated at idsPerSecond for the given number of seconds, using
+// the birthday bound. IDs can only collide within the same timestamp tick, so
+// the probability is that of any tick seeing a collision.
+func (l Layout) CollisionProbability(idsPerSecond, seconds float64) float64 {
+	if idsPerSecond <= 0 || seconds <= 0 {
+		return 0
+	}
+	ticks := float64(l.TicksPerSecond)
+	perTick := idsPerSecond / ticks
+	space := math.Ldexp(1, l.RandomBits)
+	// expected colliding pairs per tick is n²/2N; collisions across ticks are
+	// independent
+	pairs := seconds * ticks * perTick * perTick / (2 * space)
+
+	return -math.Expm1(-pairs)
+}
+
+// MaxRateFor returns the highest rate, in IDs per second, at which the
+// probability of any duplicate within a single second stays at or below
+// probability.
+func (l Layout) MaxRateFor(probability float64) float64 {
+	switch {
+	case probability <= 0:
+		return 0
+	case probability >= 1:
+		return math.Inf(1)
+	}
+	ticks := float64(l.TicksPerSecond)
+	space := math.Ldexp(1, l.RandomBits)
+	pairs := -math.Log1p(-probability)
+
+	return ticks * math.Sqrt(2*space*pairs/ticks)
+}
+
+// CollisionProbability returns the probability of at least one duplicate
+// among IDs made by New at idsPerSecond for the given number of seconds.
+func CollisionProbability(idsPerSecond, seconds float64) float64 {
+	return LayoutID.CollisionProbability(idsPerSecond, seconds)
+}
+
+// MaxRateFor returns the highest rate, in IDs per second, at which IDs made
+// by New keep the probability of a duplicate within any one second at or
+// below probability.
+func MaxRateFor(probability float64) float64 {
+	return LayoutID.MaxRateFor(probability)
+}
+
+// Layout returns the layout of IDs made by the generator. With a node
+// configured, rates apply per node.
+func (g *Generator) Layout() Layout {
+	return Layout{RandomBits: 48 - g.nodeBits, TicksPerSecond: 1}
+}
@@ -0,0 +1,68 @@
+package rid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		name         string
+		layout       Layout
+		idsPerSecond float64
+		seconds      float64
+		want         float64
+	}{
+		{"none", LayoutID, 0, 1, 0},
+		{"no time", LayoutID, 1000, 0, 0},
+		// 2^24 IDs in one second over a 48 bit space: n²/2N = 0.5
+		{"2^24 per second", LayoutID, 1 << 24, 1, 1 - math.Exp(-0.5)},
+		{"2^24 per second for a day", LayoutID, 1 << 24, 86400, 1},
+		{"1000 per second for a day", LayoutID, 1000, 86400, 1.5347e-4},
+		// 2^16 IDs per millisecond over a 32 bit space: n²/2N = 0.5 per tick
+		{"milli 2^16 per ms", LayoutMilli, 1 << 16 * 1000, 0.001, 1 - math.Exp(-0.5)},
+		{"node bits", Layout{RandomBits: 40, TicksPerSecond: 1}, 1 << 20, 1, 1 - math.Exp(-0.5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.layout.CollisionProbability(tt.idsPerSecond, tt.seconds)
+			if math.Abs(got-tt.want) > tt.want*1e-3 {
+				t.Errorf("CollisionProbability() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+	if got, want := CollisionProbability(1<<24, 1), LayoutID.CollisionProbability(1<<24, 1); got != want {
+		t.Errorf("CollisionProbability() = %g, want %g", got, want)
+	}
+}
+
+func TestMaxRateFor(t *testing.T) {
+	for _, l := range []Layout{LayoutID, LayoutMilli, {RandomBits: 40, TicksPerSecond: 1}} {
+		for _, p := range []float64{1e-12, 1e-6, 0.01, 0.5} {
+			rate := l.MaxRateFor(p)
+			if got := l.CollisionProbability(rate, 1); math.Abs(got-p) > p*1e-6 {
+				t.Errorf("%+v CollisionProbability(MaxRateFor(%g)) = %g", l, p, got)
+			}
+		}
+	}
+	if got := MaxRateFor(0); got != 0 {
+		t.Errorf("MaxRateFor(0) = %g, want 0", got)
+	}
+	if got := MaxRateFor(1); !math.IsInf(got, 1) {
+		t.Errorf("MaxRateFor(1) = %g, want +Inf", got)
+	}
+	// one in a million chance of a duplicate in a second at ~23,700 IDs/second
+	if got := MaxRateFor(1e-6); got < 23000 || got > 24000 {
+		t.Errorf("MaxRateFor(1e-6) = %g", got)
+	}
+}
+
+func TestGeneratorLayout(t *testing.T) {
+	g, err := NewGenerator(WithNode(3, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.Layout(), (Layout{RandomBits: 38, TicksPerSecond: 1}); got != want {
+		t.Errorf("Layout() = %+v, want %+v", got, want)
+	}
+}
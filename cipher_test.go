@@ -0,0 +1,82 @@
+package rid
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+var cipherKey = []byte("0123456789abcdef")
+
+// vectors cross-checked against an independent implementation using openssl
+// aes-128-ecb as the round function
+func TestCipherVectors(t *testing.T) {
+	c, err := NewCipher(cipherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		plain string
+		want  string
+	}{
+		{"0000000000000000", "qfsd4953b9tqfzkx"},
+		{"zzzzzzzzzzzzzzzz", "nlzp5nvb1htrwkd8"},
+		{"dfp7emzzzzy30ey2", "xg6typrv92dd6xyn"},
+		{"dfp7em00001p0t5j", "merdrcs4nnyn1nnr"},
+	}
+	for _, tt := range tests {
+		id, err := FromString(tt.plain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enc := c.Encrypt(id)
+		if got := enc.String(); got != tt.want {
+			t.Errorf("Encrypt(%s) = %s, want %s", tt.plain, got, tt.want)
+		}
+		if got := c.Decrypt(enc); got != id {
+			t.Errorf("Decrypt(Encrypt(%s)) = %s", tt.plain, got)
+		}
+	}
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	for _, size := range []int{16, 24, 32} {
+		key := make([]byte, size)
+		copy(key, cipherKey)
+		c, err := NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := func(id ID) bool {
+			enc := c.Encrypt(id)
+			return enc != id && c.Decrypt(enc) == id
+		}
+		if err := quick.Check(f, nil); err != nil {
+			t.Errorf("AES-%d: %v", size*8, err)
+		}
+	}
+}
+
+func TestCipherKeys(t *testing.T) {
+	a, _ := NewCipher(cipherKey)
+	b, _ := NewCipher([]byte("fedcba9876543210"))
+	id := New()
+	if a.Encrypt(id) == b.Encrypt(id) {
+		t.Error("different keys produced the same ciphertext")
+	}
+	if b.Decrypt(a.Encrypt(id)) == id {
+		t.Error("decrypted with the wrong key")
+	}
+	if _, err := NewCipher([]byte("short")); err == nil {
+		t.Error("NewCipher(short key) want error")
+	}
+}
+
+// ciphertexts of IDs made in the same second share no visible prefix
+func TestCipherHidesTimestamp(t *testing.T) {
+	c, _ := NewCipher(cipherKey)
+	a, b := NewWithTime(IDs[0].id.Time()), NewWithTime(IDs[0].id.Time())
+	ea, eb := c.Encrypt(a), c.Encrypt(b)
+	if ea[0] == eb[0] && ea[1] == eb[1] && ea[2] == eb[2] && ea[3] == eb[3] {
+		t.Errorf("ciphertexts %s, %s share a timestamp", ea, eb)
+	}
+}
@@ -11,19 +11,35 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "stats":
+			os.Exit(stats(os.Args[2:]))
+		case "serve":
+			os.Exit(serve(os.Args[2:]))
+		}
+	}
+
 	count := 1
 	flag.IntVar(&count, "c", count, "Generate N-count IDs")
+	output := flag.String("o", "text", "Output format: text, json, csv or tsv")
 	flag.Usage = func() {
 		fs := flag.CommandLine
 		fcount := fs.Lookup("c")
+		foutput := fs.Lookup("o")
 
 		fmt.Printf("Usage: rid\n\n")
 		fmt.Printf("Options:\n")
 		fmt.Printf("  rid dgm3w9sh9f5flv5s\t\tDecode the supplied Base32 ID\n")
-		fmt.Printf("  rid -%s N\t\t\t%s default: %s\n\n", fcount.Name, fcount.Usage, fcount.DefValue)
+		fmt.Printf("  rid -%s N\t\t\t%s default: %s\n", fcount.Name, fcount.Usage, fcount.DefValue)
+		fmt.Printf("  rid -%s FORMAT\t\t%s default: %s\n", foutput.Name, foutput.Usage, foutput.DefValue)
+		fmt.Printf("  rid stats -h\t\t\tCollision probability estimates\n")
+		fmt.Printf("  rid serve -h\t\t\tIssue and decode IDs over HTTP\n\n")
 		fmt.Printf("With no parameters, rid generates %s random ID encoded as Base32.\n", fcount.DefValue)
 		fmt.Printf("Generate and inspect 4 random IDs using Linux/Unix command substitution:\n")
 		fmt.Printf("  rid `rid -c 4`\n")
+		fmt.Printf("Inspect IDs as JSON, one object per line:\n")
+		fmt.Printf("  rid -o json dgm3w9sh9f5flv5s | jq .time\n")
 	}
 	flag.Parse()
 	args := flag.Args()
@@ -35,24 +51,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	w, err := newWriter(os.Stdout, *output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		os.Exit(2)
+	}
+	w.inspect = len(args) > 0
+	status := 0
 	if len(args) > 0 {
 		// attempt to decode each as an rid
 		for _, arg := range args {
 			id, err := rid.FromString(arg)
 			if err != nil {
-				fmt.Printf("[%s] %s\n", arg, err)
-				continue
+				fmt.Fprintf(os.Stderr, "[%s] %s\n", arg, err)
+				status = 1
 			}
-
-			fmt.Printf("%s ts:%d rnd:%15d %s ID{%s }\n", arg,
-				id.Timestamp(), id.Random(), id.Time(), asHex(id.Bytes()))
+			w.write(arg, id, err)
 		}
 	} else {
 		// generate one or -c N ids
 		for c := 1; c <= count; c++ {
-			fmt.Fprintf(os.Stdout, "%s\n", rid.New())
+			id := rid.New()
+			w.write(id.String(), id, nil)
 		}
 	}
+	if err := w.flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		status = 1
+	}
+	os.Exit(status)
 }
 
 func asHex(b []byte) string {
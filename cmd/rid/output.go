@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mwyvr/rid"
+)
+
+// writer prints IDs in one of the output formats, keeping the first error
+// writing to w.
+type writer struct {
+	w      io.Writer
+	csv    *csv.Writer
+	json   *json.Encoder
+	header bool // csv/tsv header written
+	err    error
+
+	inspect bool // text output details each ID rather than listing it
+}
+
+func newWriter(w io.Writer, format string) (*writer, error) {
+	out := &writer{w: w}
+	switch format {
+	case "text":
+	case "json":
+		out.json = json.NewEncoder(w)
+	case "csv", "tsv":
+		out.csv = csv.NewWriter(w)
+		if format == "tsv" {
+			out.csv.Comma = '\t'
+		}
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+
+	return out, nil
+}
+
+// result is a record with the outcome of decoding an input. For invalid
+// inputs, only ID, Valid and Error are set.
+type result struct {
+	record
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// write prints id, decoded from or encoded as s, or the error from decoding
+// s. Errors are omitted from text output, which prints them to stderr only.
+// Nothing is written after a write fails.
+func (w *writer) write(s string, id rid.ID, err error) {
+	if w.err != nil {
+		return
+	}
+	res := result{record: record{ID: s}}
+	if err != nil {
+		res.Error = err.Error()
+	} else {
+		res.record, res.Valid = newRecord(id), true
+	}
+
+	switch {
+	case w.json != nil:
+		w.err = w.json.Encode(res)
+	case w.csv != nil:
+		if !w.header {
+			w.err = w.csv.Write([]string{"id", "timestamp", "time", "random", "hex", "valid", "error"})
+			w.header = true
+		}
+		row := []string{res.ID, "", "", "", "", "false", res.Error}
+		if res.Valid {
+			row = []string{res.ID, strconv.FormatInt(res.Timestamp, 10), res.Time,
+				strconv.FormatUint(res.Random, 10), res.Hex, "true", ""}
+		}
+		if w.err == nil {
+			w.err = w.csv.Write(row)
+		}
+	case err != nil:
+	case w.inspect:
+		_, w.err = fmt.Fprintf(w.w, "%s ts:%d rnd:%15d %s ID{%s }\n", s,
+			id.Timestamp(), id.Random(), id.Time(), asHex(id.Bytes()))
+	default:
+		_, w.err = fmt.Fprintf(w.w, "%s\n", s)
+	}
+}
+
+// flush writes any buffered output, returning the first error encountered.
+func (w *writer) flush() error {
+	if w.csv != nil && w.err == nil {
+		w.csv.Flush()
+		w.err = w.csv.Error()
+	}
+
+	return w.err
+}
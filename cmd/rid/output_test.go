@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+func TestWriter(t *testing.T) {
+	valid := "dfp7emzzzzy30ey2"
+	tests := []struct {
+		format  string
+		inspect bool
+		want    string
+	}{
+		{"json", true,
+			`{"id":"dfp7emzzzzy30ey2","timestamp":1672246995,"time":"2022-12-28T17:03:15Z","random":281474912761794,"hex":"63ac76d3fffffc3037c2","valid":true}` + "\n" +
+				`{"id":"bad","timestamp":0,"time":"","random":0,"hex":"","valid":false,"error":"rid: invalid id"}` + "\n"},
+		{"csv", true,
+			"id,timestamp,time,random,hex,valid,error\n" +
+				"dfp7emzzzzy30ey2,1672246995,2022-12-28T17:03:15Z,281474912761794,63ac76d3fffffc3037c2,true,\n" +
+				"bad,,,,,false,rid: invalid id\n"},
+		{"tsv", true,
+			"id\ttimestamp\ttime\trandom\thex\tvalid\terror\n" +
+				"dfp7emzzzzy30ey2\t1672246995\t2022-12-28T17:03:15Z\t281474912761794\t63ac76d3fffffc3037c2\ttrue\t\n" +
+				"bad\t\t\t\t\tfalse\trid: invalid id\n"},
+		// the invalid input is reported on stderr only
+		{"text", false, "dfp7emzzzzy30ey2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var sb strings.Builder
+			w, err := newWriter(&sb, tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.inspect = tt.inspect
+			for _, s := range []string{valid, "bad"} {
+				id, err := rid.FromString(s)
+				w.write(s, id, err)
+			}
+			if err := w.flush(); err != nil {
+				t.Fatal(err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriterInspectText(t *testing.T) {
+	var sb strings.Builder
+	w, err := newWriter(&sb, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.inspect = true
+	id, _ := rid.FromString("dfp7emzzzzy30ey2")
+	w.write(id.String(), id, nil)
+	got := sb.String()
+	if !strings.HasPrefix(got, "dfp7emzzzzy30ey2 ts:1672246995 rnd:281474912761794 ") ||
+		!strings.HasSuffix(got, "ID{ 0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2 }\n") {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestWriterUnknownFormat(t *testing.T) {
+	if _, err := newWriter(&strings.Builder{}, "yaml"); err == nil {
+		t.Error("newWriter(yaml) want error")
+	}
+}
+
+// failWriter fails every write, as stdout does once a reading pipe closes.
+type failWriter struct{ n int }
+
+var errClosed = errors.New("broken pipe")
+
+func (f *failWriter) Write(p []byte) (int, error) {
+	f.n++
+	return 0, errClosed
+}
+
+func TestWriterError(t *testing.T) {
+	for _, format := range []string{"json", "csv", "tsv", "text"} {
+		fw := &failWriter{}
+		w, err := newWriter(fw, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := rid.New()
+		for range 3 {
+			w.write(id.String(), id, nil)
+		}
+		if err := w.flush(); !errors.Is(err, errClosed) {
+			t.Errorf("%s: flush() err = %v, want %v", format, err, errClosed)
+		}
+		if fw.n != 1 {
+			t.Errorf("%s: %d writes after failure, want 1", format, fw.n)
+		}
+	}
+}
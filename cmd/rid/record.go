@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// record describes a decoded ID for structured output.
+type record struct {
+	ID        string `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	Time      string `json:"time"`
+	Random    uint64 `json:"random"`
+	Hex       string `json:"hex"`
+}
+
+func newRecord(id rid.ID) record {
+	return record{
+		ID:        id.String(),
+		Timestamp: id.Timestamp(),
+		Time:      id.Time().UTC().Format(time.RFC3339),
+		Random:    id.Random(),
+		Hex:       hex.EncodeToString(id.Bytes()),
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+// maxBatch limits the IDs returned by a single /ids request.
+const maxBatch = 10000
+
+// serve implements the "rid serve" subcommand, issuing and decoding IDs over
+// HTTP for clients that cannot link the package.
+func serve(args []string) int {
+	fs := flag.NewFlagSet("rid serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "TCP address to listen on")
+	socket := fs.String("socket", "", "Unix socket to listen on instead of -addr")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid serve [options]\n\n")
+		fmt.Fprintf(fs.Output(), "Serve IDs over HTTP:\n")
+		fmt.Fprintf(fs.Output(), "  GET /id\t\t\tOne ID as text\n")
+		fmt.Fprintf(fs.Output(), "  GET /ids?n=N\t\t\tN IDs, one per line, up to %d\n", maxBatch)
+		fmt.Fprintf(fs.Output(), "  GET /bounds?from=T&to=T\tLowest and highest IDs for a time range as JSON\n")
+		fmt.Fprintf(fs.Output(), "  GET /inspect/{id}\t\tA decoded ID as JSON\n\n")
+		fmt.Fprintf(fs.Output(), "Times are RFC 3339 or Unix seconds.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	network, address := "tcp", *addr
+	if *socket != "" {
+		network, address = "unix", *socket
+	}
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+
+	g, err := rid.NewGenerator()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+	srv := &http.Server{Handler: newServeMux(g), ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "rid: serving on %s %s\n", network, ln.Addr())
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "rid: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// bound is a record of a range bound, with the sortable form that compares
+// correctly as a string.
+type bound struct {
+	record
+	Sortable string `json:"sortable"`
+}
+
+// newServeMux returns the handler for rid serve; g checks the range of times
+// given to /bounds.
+func newServeMux(g *rid.Generator) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /id", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, rid.New())
+	})
+	mux.HandleFunc("GET /ids", func(w http.ResponseWriter, r *http.Request) {
+		n := 1
+		if s := r.URL.Query().Get("n"); s != "" {
+			var err error
+			if n, err = strconv.Atoi(s); err != nil || n < 1 || n > maxBatch {
+				http.Error(w, fmt.Sprintf("n must be between 1 and %d", maxBatch), http.StatusBadRequest)
+				return
+			}
+		}
+		var b strings.Builder
+		b.Grow(n * 17)
+		for range n {
+			b.WriteString(rid.New().String())
+			b.WriteByte('\n')
+		}
+		w.Write([]byte(b.String()))
+	})
+	mux.HandleFunc("GET /bounds", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		from, err := parseTime(q.Get("from"))
+		if err != nil {
+			http.Error(w, "from: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		to := from
+		if s := q.Get("to"); s != "" {
+			if to, err = parseTime(s); err != nil {
+				http.Error(w, "to: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if to.Before(from) {
+			http.Error(w, "to is before from", http.StatusBadRequest)
+			return
+		}
+		lo, hi, err := g.Bounds(from, to)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, struct {
+			Min bound `json:"min"`
+			Max bound `json:"max"`
+		}{
+			bound{newRecord(lo), lo.SortableString()},
+			bound{newRecord(hi), hi.SortableString()},
+		})
+	})
+	mux.HandleFunc("GET /inspect/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := rid.FromString(r.PathValue("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, newRecord(id))
+	})
+
+	return mux
+}
+
+// parseTime parses an RFC 3339 time or Unix seconds.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, errors.New("time required")
+	}
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.New("want RFC 3339 time or Unix seconds")
+	}
+
+	return t, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+func get(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	g, err := rid.NewGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	newServeMux(g).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rec
+}
+
+func TestServeID(t *testing.T) {
+	rec := get(t, "/id")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	if _, err := rid.FromString(strings.TrimSuffix(rec.Body.String(), "\n")); err != nil {
+		t.Errorf("body %q: %v", rec.Body, err)
+	}
+}
+
+func TestServeIDs(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int // IDs, or 0 for a bad request
+	}{
+		{"", 1},
+		{"?n=1", 1},
+		{"?n=1000", 1000},
+		{"?n=10000", 10000},
+		{"?n=0", 0},
+		{"?n=-1", 0},
+		{"?n=10001", 0},
+		{"?n=ten", 0},
+	}
+	for _, tt := range tests {
+		rec := get(t, "/ids"+tt.query)
+		if tt.want == 0 {
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("/ids%s status = %d, want %d", tt.query, rec.Code, http.StatusBadRequest)
+			}
+			continue
+		}
+		lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+		if rec.Code != http.StatusOK || len(lines) != tt.want {
+			t.Errorf("/ids%s = %d, %d IDs, want %d IDs", tt.query, rec.Code, len(lines), tt.want)
+			continue
+		}
+		if _, err := rid.FromString(lines[len(lines)-1]); err != nil {
+			t.Errorf("/ids%s: %v", tt.query, err)
+		}
+	}
+}
+
+func TestServeBounds(t *testing.T) {
+	var got struct{ Min, Max bound }
+	rec := get(t, "/bounds?from=2024-06-01T00:00:00Z&to=1717286400")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := struct{ Min, Max bound }{
+		bound{record{"dse6900000000000", 1717200000, "2024-06-01T00:00:00Z", 0, "665a6480000000000000"}, "dse6900000000000"},
+		bound{record{"dsevd07zzzzzzzzz", 1717286400, "2024-06-02T00:00:00Z", 1<<48 - 1, "665bb600ffffffffffff"}, "dsevd07zzzzzzzzz"},
+	}
+	if got != want {
+		t.Errorf("/bounds = %+v, want %+v", got, want)
+	}
+
+	// to defaults to from
+	rec = get(t, "/bounds?from=1717200000")
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Min.Timestamp != 1717200000 || got.Max.Timestamp != 1717200000 {
+		t.Errorf("/bounds with from only = %+v", got)
+	}
+
+	for _, query := range []string{
+		"",
+		"?from=yesterday",
+		"?from=1717200000&to=tomorrow",
+		"?from=1717286400&to=1717200000", // reversed
+		"?from=-1",
+		"?from=4294967290&to=4294967300", // past 2106
+	} {
+		if rec := get(t, "/bounds"+query); rec.Code != http.StatusBadRequest {
+			t.Errorf("/bounds%s status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestServeInspect(t *testing.T) {
+	rec := get(t, "/inspect/dfp7emzzzzy30ey2")
+	var got record
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := record{"dfp7emzzzzy30ey2", 1672246995, "2022-12-28T17:03:15Z", 281474912761794, "63ac76d3fffffc3037c2"}
+	if rec.Code != http.StatusOK || got != want {
+		t.Errorf("/inspect = %d, %+v, want %+v", rec.Code, got, want)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	for _, id := range []string{"dfp7emzzzzy30eyu", "short"} {
+		if rec := get(t, "/inspect/"+id); rec.Code != http.StatusBadRequest {
+			t.Errorf("/inspect/%s status = %d, want %d", id, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
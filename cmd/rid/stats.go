@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mwyvr/rid"
+)
+
+type namedLayout struct {
+	name   string
+	layout rid.Layout
+}
+
+// stats implements the "rid stats" subcommand, printing collision
+// probabilities and rate limits for capacity planning.
+func stats(args []string) int {
+	fs := flag.NewFlagSet("rid stats", flag.ContinueOnError)
+	rate := fs.Float64("rate", 1000, "IDs generated per second (per node with -node)")
+	seconds := fs.Float64("seconds", 86400, "Duration of generation in seconds")
+	prob := fs.Float64("p", 1e-9, "Acceptable probability of a duplicate within one second")
+	nodeBits := fs.Int("node", 0, "Bits reserved for a node identifier, if any")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: rid stats [options]\n\n")
+		fmt.Fprintf(fs.Output(), "Print birthday-bound collision estimates for each ID layout.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *nodeBits < 0 || *nodeBits > 32 {
+		fmt.Fprintf(os.Stderr, "rid: -node must be between 0 and 32\n")
+		return 2
+	}
+
+	layouts := []namedLayout{
+		{"ID", rid.LayoutID},
+		{"MilliID", rid.LayoutMilli},
+	}
+	if *nodeBits > 0 {
+		layouts = append(layouts, namedLayout{
+			fmt.Sprintf("ID node/%d", *nodeBits),
+			rid.Layout{RandomBits: 48 - *nodeBits, TicksPerSecond: 1},
+		})
+	}
+
+	fmt.Printf("%.0f IDs/second for %.0f seconds; max rate for p=%g per second\n\n",
+		*rate, *seconds, *prob)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "Layout\tRandom bits\tTicks/s\tP(duplicate)\tMax IDs/s\t\n")
+	for _, l := range layouts {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.6g\t%.0f\t\n", l.name, l.layout.RandomBits,
+			l.layout.TicksPerSecond, l.layout.CollisionProbability(*rate, *seconds),
+			l.layout.MaxRateFor(*prob))
+	}
+	w.Flush()
+
+	return 0
+}
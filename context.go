@@ -0,0 +1,92 @@
+package rid
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Chain is the set of IDs carried by a context: the ID of the current unit of
+// work, the ID of the unit that spawned it and, for event-sourced workflows,
+// the ID of the event or command that caused it. Absent IDs are nil.
+type Chain struct {
+	ID        ID
+	Parent    ID
+	Causation ID
+}
+
+type chainKey struct{}
+
+// NewContext returns a copy of ctx carrying id. Any ID already carried by ctx
+// becomes the Parent; the causation ID is inherited.
+func NewContext(ctx context.Context, id ID) context.Context {
+	c := ChainFromContext(ctx)
+	if !c.ID.IsNil() {
+		c.Parent = c.ID
+	}
+	c.ID = id
+
+	return context.WithValue(ctx, chainKey{}, c)
+}
+
+// WithCausation returns a copy of ctx carrying id as its causation ID.
+func WithCausation(ctx context.Context, id ID) context.Context {
+	c := ChainFromContext(ctx)
+	c.Causation = id
+
+	return context.WithValue(ctx, chainKey{}, c)
+}
+
+// FromContext returns the ID carried by ctx, and whether there was one.
+func FromContext(ctx context.Context) (ID, bool) {
+	c := ChainFromContext(ctx)
+	return c.ID, !c.ID.IsNil()
+}
+
+// ChainFromContext returns the IDs carried by ctx.
+func ChainFromContext(ctx context.Context) Chain {
+	c, _ := ctx.Value(chainKey{}).(Chain)
+	return c
+}
+
+// Attribute keys added to log records by LogHandler.
+const (
+	LogKeyID        = "request_id"
+	LogKeyParent    = "parent_id"
+	LogKeyCausation = "causation_id"
+)
+
+// LogHandler is an slog.Handler adding the IDs carried by the context of each
+// record, under LogKeyID, LogKeyParent and LogKeyCausation, before passing it
+// to the wrapped handler. Nil IDs are omitted. Log with the Context variants
+// of the slog.Logger methods for the IDs to be found.
+type LogHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler returns a LogHandler wrapping h.
+func NewLogHandler(h slog.Handler) *LogHandler {
+	return &LogHandler{Handler: h}
+}
+
+// Handle adds the context's IDs to r and passes it to the wrapped handler.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if c := ChainFromContext(ctx); c != (Chain{}) {
+		r = r.Clone()
+		r.AddAttrs(Attr(LogKeyID, c.ID), Attr(LogKeyParent, c.Parent),
+			Attr(LogKeyCausation, c.Causation))
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a LogHandler wrapping the result of the wrapped handler's
+// WithAttrs.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewLogHandler(h.Handler.WithAttrs(attrs))
+}
+
+// WithGroup returns a LogHandler wrapping the result of the wrapped handler's
+// WithGroup; the IDs are then added within the group.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return NewLogHandler(h.Handler.WithGroup(name))
+}
@@ -0,0 +1,57 @@
+package rid
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	a, b, cause := New(), New(), New()
+	ctx := context.Background()
+	if id, ok := FromContext(ctx); ok || !id.IsNil() {
+		t.Errorf("FromContext(empty) = %v, %v", id, ok)
+	}
+
+	ctx = NewContext(ctx, a)
+	if id, ok := FromContext(ctx); !ok || id != a {
+		t.Errorf("FromContext() = %v, %v, want %v, true", id, ok, a)
+	}
+	ctx = WithCausation(ctx, cause)
+	child := NewContext(ctx, b)
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want Chain
+	}{
+		{"root", ctx, Chain{ID: a, Causation: cause}},
+		{"child", child, Chain{ID: b, Parent: a, Causation: cause}},
+		{"grandchild", NewContext(child, a), Chain{ID: a, Parent: b, Causation: cause}},
+	}
+	for _, tt := range tests {
+		if got := ChainFromContext(tt.ctx); got != tt.want {
+			t.Errorf("%s: ChainFromContext() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	a, b := New(), New()
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewTextHandler(&buf, nil))).With("svc", "api")
+
+	ctx := NewContext(NewContext(context.Background(), a), b)
+	logger.InfoContext(ctx, "hello")
+	want := "svc=api request_id=" + b.String() + " parent_id=" + a.String() + "\n"
+	if got := buf.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("logged %q, want suffix %q", got, want)
+	}
+
+	buf.Reset()
+	logger.InfoContext(context.Background(), "plain")
+	if got := buf.String(); !strings.HasSuffix(got, "msg=plain svc=api\n") {
+		t.Errorf("logged %q without IDs in context", got)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Package convert maps IDs from other unique ID schemes to rid.IDs, for
+migrating legacy tables while keeping time ordering. Each converter preserves
+the source timestamp, truncated to seconds, and derives the 6-byte random
+field deterministically from a hash of the source bytes, so converting the
+same ID twice gives the same result.
+
+Converters take the source ID's underlying byte array; the ID types of
+github.com/rs/xid, github.com/oklog/ulid, github.com/segmentio/ksuid and
+github.com/google/uuid may be passed directly, keeping this package free of
+dependencies:
+
+	id, loss, err := convert.FromXID(xid.New())
+
+Information discarded in conversion is reported as a Loss.
+*/
+package convert
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/mwyvr/rid"
+)
+
+// Loss is a set of flags describing information discarded in a conversion.
+type Loss uint8
+
+const (
+	// LossSubsecond reports that the source timestamp had a non-zero
+	// sub-second component, truncated to fit the seconds resolution of rid.
+	LossSubsecond Loss = 1 << iota
+
+	// LossRandom reports that the non-timestamp bits of the source, more
+	// than the 48 random bits of an ID, were compressed by hashing. Distinct
+	// source IDs from the same second may, with probability 2^-48 per pair,
+	// convert to the same ID.
+	LossRandom
+)
+
+// String returns the names of the flags set in l.
+func (l Loss) String() string {
+	if l == 0 {
+		return "none"
+	}
+	var names []string
+	if l&LossSubsecond != 0 {
+		names = append(names, "subsecond")
+	}
+	if l&LossRandom != 0 {
+		names = append(names, "random")
+	}
+	if rest := l &^ (LossSubsecond | LossRandom); rest != 0 {
+		names = append(names, fmt.Sprintf("Loss(%#x)", uint8(rest)))
+	}
+
+	return strings.Join(names, "|")
+}
+
+// ksuidEpoch is the KSUID epoch, 2014-05-13T16:53:20Z, in Unix seconds.
+const ksuidEpoch = 1400000000
+
+// FromXID converts an rs/xid ID: a 4-byte timestamp in seconds followed by
+// machine, process and counter fields.
+func FromXID(x [12]byte) (rid.ID, Loss, error) {
+	s := int64(x[0])<<24 | int64(x[1])<<16 | int64(x[2])<<8 | int64(x[3])
+
+	return build("xid", x[:], s, LossRandom)
+}
+
+// FromULID converts an oklog/ulid ULID: a 48-bit timestamp in milliseconds
+// followed by 80 random bits.
+func FromULID(u [16]byte) (rid.ID, Loss, error) {
+	return fromMillis("ulid", u[:], be48(u[:6]))
+}
+
+// FromKSUID converts a segmentio/ksuid KSUID: a 4-byte timestamp in seconds
+// since the KSUID epoch followed by 16 random bytes. KSUIDs later than 2106
+// cannot be represented and return an error wrapping rid.ErrTimeRange.
+func FromKSUID(k [20]byte) (rid.ID, Loss, error) {
+	s := int64(k[0])<<24 | int64(k[1])<<16 | int64(k[2])<<8 | int64(k[3])
+
+	return build("ksuid", k[:], s+ksuidEpoch, LossRandom)
+}
+
+// FromUUIDv7 converts an RFC 9562 version 7 UUID, such as from
+// github.com/google/uuid's NewV7: a 48-bit timestamp in milliseconds followed
+// by version, variant and random bits. Other UUID versions return an error
+// wrapping rid.ErrInvalidID.
+func FromUUIDv7(u [16]byte) (rid.ID, Loss, error) {
+	if u[6]>>4 != 7 || u[8]>>6 != 0b10 {
+		return rid.NilID(), 0, fmt.Errorf("convert: not a version 7 UUID: %w", rid.ErrInvalidID)
+	}
+
+	return fromMillis("uuidv7", u[:], be48(u[:6]))
+}
+
+// fromMillis builds an ID from a source with a millisecond timestamp.
+func fromMillis(kind string, src []byte, ms int64) (rid.ID, Loss, error) {
+	loss := LossRandom
+	if ms%1000 != 0 {
+		loss |= LossSubsecond
+	}
+
+	return build(kind, src, ms/1000, loss)
+}
+
+// build returns an ID with timestamp s, in Unix seconds, and a random field
+// hashed from the kind and bytes of the source.
+func build(kind string, src []byte, s int64, loss Loss) (rid.ID, Loss, error) {
+	if s < 0 || s > math.MaxUint32 {
+		return rid.NilID(), 0, fmt.Errorf("convert: %s timestamp %d: %w", kind, s, rid.ErrTimeRange)
+	}
+	h := sha256.New()
+	h.Write([]byte("rid/convert/" + kind + "\x00"))
+	h.Write(src)
+	sum := h.Sum(nil)
+
+	var id rid.ID
+	id[0] = byte(s >> 24)
+	id[1] = byte(s >> 16)
+	id[2] = byte(s >> 8)
+	id[3] = byte(s)
+	copy(id[4:], sum[:6])
+
+	return id, loss, nil
+}
+
+// be48 decodes a 48-bit big endian value.
+func be48(b []byte) int64 {
+	return int64(b[0])<<40 | int64(b[1])<<32 | int64(b[2])<<24 |
+		int64(b[3])<<16 | int64(b[4])<<8 | int64(b[5])
+}
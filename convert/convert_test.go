@@ -0,0 +1,130 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+// Sources are the sample IDs in the README comparison table, all generated at
+// 2025-03-04 02:26:28 UTC; expected values were computed independently.
+func TestConverters(t *testing.T) {
+	const ts = 1741055188
+	tests := []struct {
+		name string
+		fn   func() (rid.ID, Loss, error)
+		want rid.ID
+		loss Loss
+	}{
+		{
+			// cv369l5q9fa4kd8oi9a0
+			"xid",
+			func() (rid.ID, Loss, error) {
+				return FromXID([12]byte{0x67, 0xc6, 0x64, 0xd4, 0xba, 0x4b, 0xd4, 0x4a, 0x35, 0x18, 0x92, 0x54})
+			},
+			rid.ID{0x67, 0xc6, 0x64, 0xd4, 0xa9, 0xf1, 0xe9, 0x95, 0x07, 0x14},
+			LossRandom,
+		},
+		{
+			// 01JNFFKQTH2VD414Z3C1HRX676
+			"ulid",
+			func() (rid.ID, Loss, error) {
+				return FromULID([16]byte{0x01, 0x95, 0x5e, 0xf9, 0xdf, 0x51, 0x16, 0xda, 0x40, 0x93, 0xe3, 0x60, 0x63, 0x8e, 0x98, 0xe6})
+			},
+			rid.ID{0x67, 0xc6, 0x64, 0xd4, 0x6a, 0x4f, 0x16, 0x0e, 0xd3, 0x05},
+			LossSubsecond | LossRandom,
+		},
+		{
+			// 2tphzpKfP9Tj0r14XtsXw48vvGU
+			"ksuid",
+			func() (rid.ID, Loss, error) {
+				return FromKSUID([20]byte{0x14, 0x54, 0x16, 0xd4, 0x15, 0xcc, 0x9e, 0xec, 0x40, 0x33, 0xc9, 0xd1, 0x48, 0x37, 0xc9, 0xda, 0x7a, 0x2d, 0x1f, 0x1a})
+			},
+			rid.ID{0x67, 0xc6, 0x64, 0xd4, 0x95, 0xb0, 0xbc, 0xc3, 0x61, 0x0f},
+			LossRandom,
+		},
+		{
+			// 01955ef9-df51-7d46-9d2b-f01195e3fa80
+			"uuidv7",
+			func() (rid.ID, Loss, error) {
+				return FromUUIDv7([16]byte{0x01, 0x95, 0x5e, 0xf9, 0xdf, 0x51, 0x7d, 0x46, 0x9d, 0x2b, 0xf0, 0x11, 0x95, 0xe3, 0xfa, 0x80})
+			},
+			rid.ID{0x67, 0xc6, 0x64, 0xd4, 0x61, 0x9c, 0x2d, 0xa8, 0x2c, 0xc4},
+			LossSubsecond | LossRandom,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, loss, err := tt.fn()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ID = %#v, want %#v", got, tt.want)
+			}
+			if got.Timestamp() != ts {
+				t.Errorf("Timestamp() = %d, want %d", got.Timestamp(), ts)
+			}
+			if loss != tt.loss {
+				t.Errorf("loss = %v, want %v", loss, tt.loss)
+			}
+			// deterministic
+			if again, _, _ := tt.fn(); again != got {
+				t.Errorf("second conversion = %v, want %v", again, got)
+			}
+		})
+	}
+}
+
+func TestFromULIDWholeSecond(t *testing.T) {
+	// 1741055188000 ms has no sub-second component to lose
+	_, loss, err := FromULID([16]byte{0x01, 0x95, 0x5e, 0xf9, 0xdc, 0x20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loss != LossRandom {
+		t.Errorf("loss = %v, want %v", loss, LossRandom)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	// version 4
+	v4 := [16]byte{0xcb, 0x97, 0x8d, 0x85, 0xa7, 0x10, 0x48, 0x8b, 0xbe, 0xbd}
+	if _, _, err := FromUUIDv7(v4); !errors.Is(err, rid.ErrInvalidID) {
+		t.Errorf("FromUUIDv7(v4) err = %v, want %v", err, rid.ErrInvalidID)
+	}
+	// a KSUID from after 2106
+	late := [20]byte{0xff, 0xff, 0xff, 0xff}
+	if _, _, err := FromKSUID(late); !errors.Is(err, rid.ErrTimeRange) {
+		t.Errorf("FromKSUID(late) err = %v, want %v", err, rid.ErrTimeRange)
+	}
+	// a ULID from the year 10889
+	if _, _, err := FromULID([16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); !errors.Is(err, rid.ErrTimeRange) {
+		t.Errorf("FromULID(max) err = %v, want %v", err, rid.ErrTimeRange)
+	}
+}
+
+func TestSameBytesDifferentSources(t *testing.T) {
+	var b [16]byte
+	b[6], b[8] = 0x70, 0x80 // valid as a UUIDv7 and a ULID
+	u, _, _ := FromULID(b)
+	v, _, _ := FromUUIDv7(b)
+	if u == v {
+		t.Errorf("ULID and UUIDv7 of the same bytes converted to the same ID %v", u)
+	}
+}
+
+func TestLossString(t *testing.T) {
+	for l, want := range map[Loss]string{
+		0:                          "none",
+		LossSubsecond:              "subsecond",
+		LossRandom:                 "random",
+		LossSubsecond | LossRandom: "subsecond|random",
+		LossRandom | 0x80:          "random|Loss(0x80)",
+	} {
+		if got := l.String(); got != want {
+			t.Errorf("Loss(%d).String() = %q, want %q", uint8(l), got, want)
+		}
+	}
+}
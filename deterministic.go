@@ -0,0 +1,54 @@
+package rid
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// NewDeterministic returns a Generator whose output is fully determined by
+// seed and clock, reproducible across runs and platforms: the random field is
+// drawn from a ChaCha8 stream seeded with seed, and New takes its time from
+// clock. It is intended for golden-file tests and examples.
+//
+// NOT FOR PRODUCTION USE: IDs are predictable to anyone knowing the seed.
+//
+// The sequence is reproducible only if IDs are generated in the same order;
+// concurrent callers are safe but interleave unpredictably. opts are applied
+// as for NewGenerator.
+func NewDeterministic(seed uint64, clock func() time.Time, opts ...Option) (*Generator, error) {
+	if clock == nil {
+		return nil, errors.New("rid: nil clock")
+	}
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+
+	return NewGenerator(append([]Option{
+		WithClock(clock),
+		withRandom(&lockedReader{r: rand.NewChaCha8(key)}),
+	}, opts...)...)
+}
+
+// withRandom replaces crypto/rand as the source of the random field.
+func withRandom(r io.Reader) Option {
+	return func(g *Generator) error {
+		g.rand = r
+		return nil
+	}
+}
+
+// lockedReader serializes reads from a source not safe for concurrent use.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (lr *lockedReader) Read(b []byte) (int, error) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+
+	return lr.r.Read(b)
+}
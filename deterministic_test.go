@@ -0,0 +1,78 @@
+package rid
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+func TestNewDeterministic(t *testing.T) {
+	clock := fixedClock(time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC))
+	generate := func(seed uint64) []ID {
+		g, err := NewDeterministic(seed, clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]ID, 10)
+		for i := range ids {
+			if ids[i], err = g.New(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return ids
+	}
+	a, b, c := generate(1), generate(1), generate(2)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Errorf("seed 1, ID %d: %v != %v", i, a[i], b[i])
+		}
+		if a[i] == c[i] {
+			t.Errorf("seeds 1 and 2, ID %d: both %v", i, a[i])
+		}
+	}
+	// golden values guard against the sequence changing across releases
+	if got, want := a[0].String(), "dsehth3bwsw3ylxx"; got != want {
+		t.Errorf("seed 1 first ID = %s, want %s", got, want)
+	}
+	if _, err := NewDeterministic(1, nil); err == nil {
+		t.Error("NewDeterministic(nil clock) want error")
+	}
+}
+
+func TestNewDeterministicOptions(t *testing.T) {
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	g, err := NewDeterministic(1, fixedClock(epoch.Add(time.Hour)), WithEpoch(epoch), WithNode(5, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.Time(id), epoch.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+	if got := g.Node(id); got != 5 {
+		t.Errorf("Node() = %d, want 5", got)
+	}
+}
+
+func ExampleNewDeterministic() {
+	clock := func() time.Time { return time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC) }
+	g, err := NewDeterministic(42, clock)
+	if err != nil {
+		panic(err)
+	}
+	for range 3 {
+		id, _ := g.New()
+		fmt.Println(id, g.Time(id).UTC())
+	}
+	// Output:
+	// dsehth1260gvkp19 2024-06-01 12:00:00 +0000 UTC
+	// dsehth3rvcr0gd2p 2024-06-01 12:00:00 +0000 UTC
+	// dsehth0mjtgl80yh 2024-06-01 12:00:00 +0000 UTC
+}
@@ -0,0 +1,334 @@
+package rid
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrTimeRange is returned when a time cannot be represented by the
+	// 4-byte timestamp of an ID relative to the generator's epoch.
+	ErrTimeRange = errors.New("rid: time out of range")
+
+	// ErrClockRegression is returned by Generator.New under ClockError when
+	// the clock has stepped back behind the last issued timestamp.
+	ErrClockRegression = errors.New("rid: clock moved backwards")
+)
+
+// ClockPolicy determines how a Generator responds when the clock steps
+// backwards, as after an NTP correction or VM migration.
+type ClockPolicy int
+
+const (
+	// ClockAllow issues IDs with whatever time the clock reports; this is
+	// the behaviour of the package level New.
+	ClockAllow ClockPolicy = iota
+	// ClockHold issues IDs with the last issued timestamp until the clock
+	// catches up.
+	ClockHold
+	// ClockWait sleeps until the clock catches up.
+	ClockWait
+	// ClockError returns ErrClockRegression.
+	ClockError
+)
+
+// Generator produces IDs according to a set of options. Package level New and
+// NewWithTime remain the fast path for the default layout; a Generator is for
+// applications needing more control. A Generator is safe for concurrent use.
+type Generator struct {
+	epoch  int64 // Unix seconds; ID timestamps are an offset from this
+	now    func() time.Time
+	sleep  func(time.Duration)
+	policy ClockPolicy
+	path   string    // state file, if any
+	rand   io.Reader // source of the random field; crypto/rand if nil
+
+	node     uint32
+	nodeBits int // high bits of the random field reserved for node
+
+	guard *dupGuard
+
+	mu    sync.Mutex
+	state *stateFile
+	last  int64 // Unix seconds of the last ID issued by New, unless ClockAllow
+
+	issued      atomic.Uint64
+	regressions atomic.Uint64
+	duplicates  atomic.Uint64
+	rate        rateCounter
+}
+
+// Stats reports counters describing a Generator's activity. There is no
+// count of random source failures, as crypto/rand.Read crashes the program
+// rather than fail, nor of counter overflows, as IDs have no monotonic
+// counter.
+type Stats struct {
+	Issued           uint64 // IDs issued
+	ClockRegressions uint64 // times New found the clock behind the last issued timestamp
+	Duplicates       uint64 // collisions caught and regenerated by the duplicate guard
+}
+
+// Option configures a Generator.
+type Option func(*Generator) error
+
+// NewGenerator returns a Generator configured by opts.
+func NewGenerator(opts ...Option) (*Generator, error) {
+	g := &Generator{now: time.Now, sleep: time.Sleep}
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return nil, err
+		}
+	}
+	if g.path != "" {
+		st, last, err := openState(g.path)
+		if err != nil {
+			return nil, err
+		}
+		g.state, g.last = st, last
+		if g.policy == ClockAllow {
+			g.policy = ClockHold
+		}
+	}
+
+	return g, nil
+}
+
+// WithEpoch sets a custom epoch, truncated to seconds, from which ID
+// timestamps are stored as an offset. An epoch of 2020-01-01 extends the
+// range of representable times to 2156. IDs made with a custom epoch must be
+// decoded with Generator.Time; ID.Time assumes the Unix epoch.
+func WithEpoch(t time.Time) Option {
+	return func(g *Generator) error {
+		g.epoch = t.Unix()
+		return nil
+	}
+}
+
+// WithClock sets the source of the current time used by New; time.Now is
+// used by default.
+func WithClock(now func() time.Time) Option {
+	return func(g *Generator) error {
+		if now == nil {
+			return errors.New("rid: nil clock")
+		}
+		g.now = now
+		return nil
+	}
+}
+
+// WithClockPolicy sets how New responds to the clock stepping backwards. With
+// any policy but ClockAllow, the generator remembers the last second issued
+// and serializes calls to New.
+func WithClockPolicy(p ClockPolicy) Option {
+	return func(g *Generator) error {
+		if p < ClockAllow || p > ClockError {
+			return fmt.Errorf("rid: unknown clock policy %d", p)
+		}
+		g.policy = p
+		return nil
+	}
+}
+
+// WithStateFile persists the highest timestamp issued by New to the file at
+// path, reloading it when the generator is created so that IDs never go
+// backwards across process restarts. Each new second is written and synced
+// before an ID bearing it is issued. The file is locked for the life of the
+// generator; call Close to release it. The clock policy defaults to ClockHold
+// rather than ClockAllow. Locking is supported on Unix platforms only.
+func WithStateFile(path string) Option {
+	return func(g *Generator) error {
+		if path == "" {
+			return errors.New("rid: empty state file path")
+		}
+		g.path = path
+		return nil
+	}
+}
+
+// New returns a new ID using the current time, applying the generator's
+// ClockPolicy if the clock has stepped backwards.
+func (g *Generator) New() (ID, error) {
+	if g.policy == ClockAllow {
+		now := g.now()
+		id, err := g.issue(now, true)
+		if err != nil {
+			return nilID, err
+		}
+		g.rate.add(now.Unix())
+
+		return id, nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	t := now
+	if t.Unix() < g.last {
+		g.regressions.Add(1)
+		switch g.policy {
+		case ClockHold:
+			t = time.Unix(g.last, 0)
+		case ClockWait:
+			for t.Unix() < g.last {
+				g.sleep(time.Unix(g.last, 0).Sub(t))
+				now = g.now()
+				t = now
+			}
+		case ClockError:
+			return nilID, fmt.Errorf("%w: %s is behind %s", ErrClockRegression,
+				t.UTC().Format(time.RFC3339), time.Unix(g.last, 0).UTC().Format(time.RFC3339))
+		}
+	}
+	if _, err := g.offset(t); err != nil {
+		return nilID, err
+	}
+	if g.state != nil && t.Unix() > g.last {
+		if err := g.state.store(t.Unix()); err != nil {
+			return nilID, err
+		}
+	}
+	id, err := g.issue(t, true)
+	if err != nil {
+		return nilID, err
+	}
+	g.last = t.Unix()
+	g.rate.add(now.Unix())
+
+	return id, nil
+}
+
+// Close releases the generator's state file, if any. A generator must not be
+// used after Close.
+func (g *Generator) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state == nil {
+		return nil
+	}
+	err := g.state.close()
+	g.state = nil
+
+	return err
+}
+
+// NewWithTime returns a new ID using the supplied time. Unlike the package
+// level NewWithTime, times before the epoch or beyond the 4-byte range
+// following it return ErrTimeRange rather than wrapping. The ClockPolicy does
+// not apply; backdated IDs are assumed intentional.
+func (g *Generator) NewWithTime(t time.Time) (ID, error) {
+	return g.issue(t, false)
+}
+
+// issue returns a new ID bearing t; clock is true for IDs from New.
+func (g *Generator) issue(t time.Time, clock bool) (ID, error) {
+	var id ID
+
+	s, err := g.offset(t)
+	if err != nil {
+		return nilID, err
+	}
+	id[0] = byte(s >> 24)
+	id[1] = byte(s >> 16)
+	id[2] = byte(s >> 8)
+	id[3] = byte(s)
+	for attempt := 0; ; attempt++ {
+		if err := g.read(id[4:]); err != nil {
+			return nilID, err
+		}
+		g.setNode(&id)
+		if g.guard == nil || g.guard.add(id, clock) {
+			break
+		}
+		g.duplicates.Add(1)
+		if g.guard.onCollision != nil {
+			g.guard.onCollision(id)
+		}
+		if attempt == guardAttempts {
+			return nilID, ErrDuplicateID
+		}
+	}
+	g.issued.Add(1)
+
+	return id, nil
+}
+
+// read fills b from the generator's random source.
+func (g *Generator) read(b []byte) error {
+	if g.rand == nil {
+		rand.Read(b)
+		return nil
+	}
+	if _, err := io.ReadFull(g.rand, b); err != nil {
+		return fmt.Errorf("rid: reading random source: %w", err)
+	}
+
+	return nil
+}
+
+// Stats returns a snapshot of the generator's counters.
+func (g *Generator) Stats() Stats {
+	return Stats{
+		Issued:           g.issued.Load(),
+		ClockRegressions: g.regressions.Load(),
+		Duplicates:       g.duplicates.Load(),
+	}
+}
+
+// offset returns the number of seconds between the epoch and t.
+func (g *Generator) offset(t time.Time) (uint32, error) {
+	s := t.Unix() - g.epoch
+	if s < 0 || s > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: %s not within %s and %s", ErrTimeRange,
+			t.UTC().Format(time.RFC3339), g.Epoch().Format(time.RFC3339),
+			g.Epoch().Add(math.MaxUint32*time.Second).Format(time.RFC3339))
+	}
+
+	return uint32(s), nil
+}
+
+// Bounds returns the lowest and highest possible IDs with timestamps from the
+// second of from to the second of to, for range scans over IDs compared in
+// binary or sortable form. Times outside the generator's range return
+// ErrTimeRange.
+func (g *Generator) Bounds(from, to time.Time) (lo, hi ID, err error) {
+	f, err := g.offset(from)
+	if err != nil {
+		return nilID, nilID, err
+	}
+	t, err := g.offset(to)
+	if err != nil {
+		return nilID, nilID, err
+	}
+	lo[0], lo[1], lo[2], lo[3] = byte(f>>24), byte(f>>16), byte(f>>8), byte(f)
+	hi[0], hi[1], hi[2], hi[3] = byte(t>>24), byte(t>>16), byte(t>>8), byte(t)
+	for i := 4; i < rawLen; i++ {
+		hi[i] = 0xff
+	}
+
+	return lo, hi, nil
+}
+
+// Epoch returns the generator's epoch in UTC.
+func (g *Generator) Epoch() time.Time {
+	return time.Unix(g.epoch, 0).UTC()
+}
+
+// Timestamp returns the timestamp of id as seconds since the Unix epoch,
+// decoded relative to the generator's epoch.
+func (g *Generator) Timestamp(id ID) int64 {
+	return g.epoch + id.Timestamp()
+}
+
+// Time returns the timestamp of id as a Time value, decoded relative to the
+// generator's epoch.
+func (g *Generator) Time(id ID) time.Time {
+	return time.Unix(g.Timestamp(id), 0)
+}
@@ -0,0 +1,246 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGeneratorDefaultEpoch(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	id, err := g.NewWithTime(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// with the Unix epoch, IDs are identical in layout to package level IDs
+	if got, want := id.Timestamp(), ts.Unix(); got != want {
+		t.Errorf("Timestamp() = %v, want %v", got, want)
+	}
+	if got := g.Time(id); !got.Equal(id.Time()) {
+		t.Errorf("Generator.Time() = %v, want %v", got, id.Time())
+	}
+}
+
+func TestGeneratorEpoch(t *testing.T) {
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	g, err := NewGenerator(WithEpoch(epoch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Epoch(); !got.Equal(epoch) {
+		t.Errorf("Epoch() = %v, want %v", got, epoch)
+	}
+	tests := []struct {
+		name    string
+		t       time.Time
+		wantErr bool
+	}{
+		{"epoch", epoch, false},
+		{"after 2106", time.Date(2150, time.June, 1, 0, 0, 0, 0, time.UTC), false},
+		{"last second", epoch.Add((1<<32 - 1) * time.Second), false},
+		{"before epoch", epoch.Add(-time.Second), true},
+		{"past range", epoch.Add((1 << 32) * time.Second), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := g.NewWithTime(tt.t)
+			if tt.wantErr {
+				if !errors.Is(err, ErrTimeRange) {
+					t.Errorf("NewWithTime() err = %v, want %v", err, ErrTimeRange)
+				}
+				if !id.IsNil() {
+					t.Errorf("NewWithTime() = %v, want nil ID", id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.Time(id); !got.Equal(tt.t) {
+				t.Errorf("Time() = %v, want %v", got, tt.t)
+			}
+			if got, want := g.Timestamp(id), tt.t.Unix(); got != want {
+				t.Errorf("Timestamp() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGeneratorEpochHistorical(t *testing.T) {
+	epoch := time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+	g, err := NewGenerator(WithEpoch(epoch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(1912, time.April, 15, 2, 20, 0, 0, time.UTC)
+	id, err := g.NewWithTime(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Time(id); !got.Equal(ts) {
+		t.Errorf("Time() = %v, want %v", got, ts)
+	}
+	// package level decoding knows nothing of the epoch
+	if id.Time().Equal(ts) {
+		t.Errorf("ID.Time() unexpectedly decoded relative to custom epoch")
+	}
+}
+
+func TestGeneratorNew(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now().Unix()
+	id, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts := id.Timestamp(); ts < before || ts > time.Now().Unix() {
+		t.Errorf("New() timestamp %d out of range", ts)
+	}
+}
+
+// fakeClock is an injectable clock for tests; sleeping advances it.
+type fakeClock struct {
+	t     time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.slept += d
+	c.t = c.t.Add(d)
+}
+
+func TestGeneratorClockPolicy(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		policy   ClockPolicy
+		wantTime time.Time // of the ID issued after the clock steps back
+		wantErr  error
+	}{
+		{ClockAllow, start.Add(-5 * time.Second), nil},
+		{ClockHold, start, nil},
+		{ClockWait, start, nil},
+		{ClockError, time.Time{}, ErrClockRegression},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("policy%d", tt.policy), func(t *testing.T) {
+			clock := &fakeClock{t: start}
+			g, err := NewGenerator(WithClock(clock.Now), WithClockPolicy(tt.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.sleep = clock.Sleep
+			if _, err := g.New(); err != nil {
+				t.Fatal(err)
+			}
+			clock.t = start.Add(-5*time.Second + 500*time.Millisecond)
+			id, err := g.New()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("New() err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !id.IsNil() {
+					t.Errorf("New() = %v, want nil ID", id)
+				}
+			} else if got := id.Time(); !got.Equal(tt.wantTime) {
+				t.Errorf("New() time = %v, want %v", got.UTC(), tt.wantTime)
+			}
+			wantRegressions := uint64(1)
+			if tt.policy == ClockAllow {
+				wantRegressions = 0
+			}
+			if got := g.Stats().ClockRegressions; got != wantRegressions {
+				t.Errorf("Stats().ClockRegressions = %d, want %d", got, wantRegressions)
+			}
+			if tt.policy == ClockWait && clock.slept != 4500*time.Millisecond {
+				t.Errorf("slept %v, want %v", clock.slept, 4500*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestGeneratorClockHoldRecovers(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{t: start}
+	g, err := NewGenerator(WithClock(clock.Now), WithClockPolicy(ClockHold))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var prev ID
+	// clock steps back two seconds, then advances a second at a time
+	for i, offset := range []int{0, -2, -1, 0, 1, 2} {
+		clock.t = start.Add(time.Duration(offset) * time.Second)
+		id, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.Timestamp() < prev.Timestamp() {
+			t.Errorf("step %d: New() %v went backwards from %v", i, id.Time(), prev.Time())
+		}
+		prev = id
+	}
+	if got := prev.Time(); !got.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("final time = %v, want %v", got, start.Add(2*time.Second))
+	}
+	if got, want := g.Stats(), (Stats{Issued: 6, ClockRegressions: 2}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGeneratorBounds(t *testing.T) {
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	g, err := NewGenerator(WithEpoch(epoch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := epoch.Add(time.Hour + 500*time.Millisecond)
+	to := from.Add(time.Minute)
+	lo, hi, err := g.Bounds(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ID{0, 0, 0x0e, 0x10}); lo != want {
+		t.Errorf("Bounds() lo = %v, want %v", lo.Bytes(), want.Bytes())
+	}
+	if want := (ID{0, 0, 0x0e, 0x4c, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); hi != want {
+		t.Errorf("Bounds() hi = %v, want %v", hi.Bytes(), want.Bytes())
+	}
+	for range 100 {
+		id, err := g.NewWithTime(from.Add(30 * time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.Compare(lo) < 0 || id.Compare(hi) > 0 {
+			t.Errorf("%v not within bounds", id)
+		}
+		if s := id.SortableString(); s < lo.SortableString() || s > hi.SortableString() {
+			t.Errorf("%v not within sortable bounds", id)
+		}
+	}
+	for _, tc := range [][2]time.Time{
+		{epoch.Add(-time.Second), to},
+		{from, epoch.Add((1 << 32) * time.Second)},
+	} {
+		if lo, hi, err := g.Bounds(tc[0], tc[1]); !errors.Is(err, ErrTimeRange) || !lo.IsNil() || !hi.IsNil() {
+			t.Errorf("Bounds(%v, %v) = %v, %v, %v, want %v", tc[0], tc[1], lo, hi, err, ErrTimeRange)
+		}
+	}
+}
+
+func TestGeneratorOptionErrors(t *testing.T) {
+	if _, err := NewGenerator(WithClock(nil)); err == nil {
+		t.Error("WithClock(nil) want error")
+	}
+	if _, err := NewGenerator(WithClockPolicy(ClockPolicy(42))); err == nil {
+		t.Error("WithClockPolicy(42) want error")
+	}
+}
@@ -4,3 +4,10 @@ go 1.24
 
 // Package rid has no dependencies outside of the Go standard library.
 // If running anything under eval/* run `go mod tidy` to pull in dependencies.
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/oklog/ulid v1.3.1
+	github.com/rs/xid v1.6.0
+	github.com/segmentio/ksuid v1.0.4
+)
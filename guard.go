@@ -0,0 +1,106 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrDuplicateID is returned when the duplicate guard cannot find an unused
+// random value for an ID, meaning the random space for the second is
+// effectively exhausted.
+var ErrDuplicateID = errors.New("rid: duplicate id")
+
+const (
+	guardShards   = 64 // power of two; spreads lock contention
+	guardAttempts = 64 // regenerations before giving up
+)
+
+// dupGuard remembers the IDs issued in the current and previous second so
+// collisions can be regenerated. Memory is bounded by limit IDs per second.
+//
+// Once the generator has issued an ID with New, only New moves the window:
+// an ID from NewWithTime dated outside it, whether backdated or in the
+// future, is issued unchecked rather than evicting the live seconds.
+type dupGuard struct {
+	shards      [guardShards]guardShard
+	limit       int // per shard, per second
+	onCollision func(ID)
+	clocked     atomic.Bool // an ID from New has been added
+}
+
+type guardShard struct {
+	mu   sync.Mutex
+	sec  int64 // timestamp of cur
+	cur  map[ID]struct{}
+	prev map[ID]struct{}
+}
+
+// WithDuplicateGuard makes the generator remember the IDs it issued in the
+// current and previous second, regenerating the random field of any ID that
+// collides and calling onCollision, if not nil, with the duplicate. At most
+// limit IDs per second are remembered; beyond that, IDs are issued unchecked.
+// Roughly 40 bytes are needed per remembered ID. The window follows the clock
+// of New; IDs from NewWithTime dated outside it are issued unchecked.
+func WithDuplicateGuard(limit int, onCollision func(ID)) Option {
+	return func(g *Generator) error {
+		if limit < 1 {
+			return fmt.Errorf("rid: duplicate guard limit %d must be positive", limit)
+		}
+		g.guard = &dupGuard{
+			limit:       max(limit/guardShards, 1),
+			onCollision: onCollision,
+		}
+		return nil
+	}
+}
+
+// add records id, returning false if it was already issued. clock is true
+// for IDs from New, which bear the clock's time.
+func (dg *dupGuard) add(id ID, clock bool) bool {
+	if clock && !dg.clocked.Load() {
+		dg.clocked.Store(true)
+	}
+	ts := id.Timestamp()
+	s := &dg.shards[id[9]&(guardShards-1)]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case ts > s.sec && !clock && dg.clocked.Load():
+		// outside the window, as for backdated IDs
+		return true
+	case ts > s.sec:
+		if ts == s.sec+1 {
+			s.prev = s.cur
+		} else {
+			s.prev = nil
+		}
+		s.cur = make(map[ID]struct{})
+		s.sec = ts
+	case ts == s.sec-1:
+		if _, ok := s.prev[id]; ok {
+			return false
+		}
+		if s.prev != nil && len(s.prev) < dg.limit {
+			s.prev[id] = struct{}{}
+		}
+		return true
+	case ts < s.sec:
+		// outside the window, as for backdated IDs
+		return true
+	}
+	if _, ok := s.cur[id]; ok {
+		return false
+	}
+	if s.cur == nil {
+		s.cur = make(map[ID]struct{})
+	}
+	if len(s.cur) < dg.limit {
+		s.cur[id] = struct{}{}
+	}
+
+	return true
+}
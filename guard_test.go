@@ -0,0 +1,148 @@
+package rid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDuplicateGuard(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		collisions []ID
+	)
+	// a 32 bit node leaves 16 random bits, making collisions routine
+	g, err := NewGenerator(
+		WithNode(7, 32),
+		WithDuplicateGuard(1<<20, func(id ID) {
+			mu.Lock()
+			collisions = append(collisions, id)
+			mu.Unlock()
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	seen := make(map[ID]bool)
+	for i := range 5000 {
+		id, err := g.NewWithTime(ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate %v issued at %d", id, i)
+		}
+		seen[id] = true
+	}
+	stats := g.Stats()
+	if stats.Duplicates == 0 {
+		t.Error("Stats().Duplicates = 0, expected collisions in a 16 bit space")
+	}
+	if got := uint64(len(collisions)); got != stats.Duplicates {
+		t.Errorf("callback called %d times, Stats().Duplicates = %d", got, stats.Duplicates)
+	}
+	for _, id := range collisions {
+		if !seen[id] {
+			t.Errorf("collision %v reported but never issued", id)
+		}
+	}
+	if stats.Issued != 5000 {
+		t.Errorf("Stats().Issued = %d, want 5000", stats.Issued)
+	}
+}
+
+func TestDuplicateGuardWindow(t *testing.T) {
+	dg := &dupGuard{limit: 10}
+	id := ID{0, 0, 0, 10, 1, 2, 3, 4, 5, 6}
+	if !dg.add(id, true) {
+		t.Fatal("add() of new ID = false")
+	}
+	if dg.add(id, true) {
+		t.Error("add() of duplicate in current second = true")
+	}
+	// next second: the first is still remembered as the previous second
+	next := ID{0, 0, 0, 11, 1, 2, 3, 4, 5, 6}
+	if !dg.add(next, true) {
+		t.Error("add() in next second = false")
+	}
+	if dg.add(id, true) {
+		t.Error("add() of duplicate in previous second = true")
+	}
+	// two seconds on, the first is forgotten
+	later := ID{0, 0, 0, 13, 1, 2, 3, 4, 5, 6}
+	if !dg.add(later, true) {
+		t.Error("add() two seconds later = false")
+	}
+	if !dg.add(id, true) {
+		t.Error("add() outside the window = false")
+	}
+
+	// a future ID from NewWithTime must not evict the live window
+	live := ID{0, 0, 0, 20, 1, 2, 3, 4, 5, 7}
+	future := ID{0, 0, 0, 99, 1, 2, 3, 4, 5, 7} // same shard
+	if !dg.add(live, true) {
+		t.Fatal("add() of live ID = false")
+	}
+	if !dg.add(future, false) {
+		t.Error("add() of future ID = false")
+	}
+	if dg.add(live, true) {
+		t.Error("add() of live duplicate after a future ID = true")
+	}
+	dup := ID{0, 0, 0, 20, 9, 9, 9, 9, 9, 7}
+	if !dg.add(dup, false) || dg.add(dup, true) {
+		t.Error("NewWithTime ID within the window not remembered")
+	}
+}
+
+func TestDuplicateGuardWithoutClock(t *testing.T) {
+	// a generator used only through NewWithTime moves the window itself
+	dg := &dupGuard{limit: 10}
+	a := ID{0, 0, 0, 10, 1, 2, 3, 4, 5, 6}
+	b := ID{0, 0, 0, 30, 1, 2, 3, 4, 5, 6}
+	for _, id := range []ID{a, b} {
+		if !dg.add(id, false) {
+			t.Fatalf("add(%v) = false", id)
+		}
+		if dg.add(id, false) {
+			t.Errorf("add(%v) of duplicate = true", id)
+		}
+	}
+}
+
+func TestDuplicateGuardLimit(t *testing.T) {
+	dg := &dupGuard{limit: 1}
+	a := ID{0, 0, 0, 10, 0, 0, 0, 0, 0, 0}
+	b := ID{0, 0, 0, 10, 1, 0, 0, 0, 0, 0} // same shard as a
+	dg.add(a, true)
+	dg.add(b, true)
+	// b was not remembered once the shard reached its limit
+	if !dg.add(b, true) {
+		t.Error("add() beyond limit remembered the ID")
+	}
+	if dg.add(a, true) {
+		t.Error("add() of remembered duplicate = true")
+	}
+}
+
+func TestDuplicateGuardExhausted(t *testing.T) {
+	g, err := NewGenerator(WithNode(0, 32), WithDuplicateGuard(1<<20, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	// 16 random bits can't hold more than 65536 IDs in one second
+	for range 1 << 16 {
+		if _, err = g.NewWithTime(ts); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("NewWithTime() err = %v, want %v", err, ErrDuplicateID)
+	}
+	if _, err := NewGenerator(WithDuplicateGuard(0, nil)); err == nil {
+		t.Error("WithDuplicateGuard(0) want error")
+	}
+}
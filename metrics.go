@@ -0,0 +1,82 @@
+package rid
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// rateCounter counts events in the current and previous second of a clock.
+// Counting in the current second takes no lock. The window never moves
+// backwards: events for the previous second, as from callers that read the
+// clock just before it ticked, count towards it, and older ones are dropped.
+type rateCounter struct {
+	mu   sync.Mutex
+	sec  atomic.Int64
+	cur  atomic.Uint64
+	prev atomic.Uint64
+}
+
+// add counts an event in second sec.
+func (r *rateCounter) add(sec int64) {
+	if r.sec.Load() == sec {
+		r.cur.Add(1)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch cur := r.sec.Load(); {
+	case sec == cur:
+		r.cur.Add(1)
+	case sec == cur-1:
+		r.prev.Add(1)
+	case sec < cur:
+		// too late to count
+	case sec == cur+1:
+		r.prev.Store(r.cur.Swap(1))
+		r.sec.Store(sec)
+	default:
+		r.prev.Store(0)
+		r.cur.Store(1)
+		r.sec.Store(sec)
+	}
+}
+
+// last returns the count for the second before sec.
+func (r *rateCounter) last(sec int64) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.sec.Load() {
+	case sec:
+		return r.prev.Load()
+	case sec - 1:
+		return r.cur.Load()
+	}
+	return 0
+}
+
+// Rate returns the number of IDs issued during the last complete second of
+// the generator's clock, counting IDs from New towards the second the clock
+// read when they were issued. IDs from NewWithTime, which may bear any time,
+// are not counted.
+func (g *Generator) Rate() uint64 {
+	return g.rate.last(g.now().Unix())
+}
+
+// PublishExpvar publishes the generator's Stats and Rate as an expvar map
+// under name, served at /debug/vars by expvar's handler. Like expvar.Publish,
+// it panics if name is already in use.
+func (g *Generator) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		s := g.Stats()
+		return map[string]uint64{
+			"issued":            s.Issued,
+			"ids_per_second":    g.Rate(),
+			"clock_regressions": s.ClockRegressions,
+			"duplicates":        s.Duplicates,
+		}
+	}))
+}
@@ -0,0 +1,128 @@
+package rid
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+)
+
+func TestGeneratorRate(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{t: start}
+	g, err := NewGenerator(WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := []struct {
+		advance time.Duration // before issuing
+		issue   int
+		want    uint64
+	}{
+		{0, 5, 0}, // no complete second yet
+		{time.Second, 3, 5},
+		{500 * time.Millisecond, 2, 5},
+		{time.Second, 0, 5}, // 3+2 issued in the previous second
+		{time.Second, 0, 0},
+		{10 * time.Second, 4, 0},
+		{-10 * time.Second, 1, 0}, // clock stepped back
+	}
+	for i, s := range steps {
+		clock.t = clock.t.Add(s.advance)
+		for range s.issue {
+			if _, err := g.New(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := g.Rate(); got != s.want {
+			t.Errorf("step %d: Rate() = %d, want %d", i, got, s.want)
+		}
+	}
+}
+
+func TestRateCounterLateAdd(t *testing.T) {
+	var r rateCounter
+	for range 501 {
+		r.add(100)
+	}
+	r.add(101)
+	// a caller that read the clock before it ticked counts late
+	r.add(100)
+	if got := r.last(101); got != 502 {
+		t.Errorf("last(101) = %d, want 502", got)
+	}
+	// too late for the window, and must not move it back
+	r.add(99)
+	r.add(101)
+	if got := r.last(102); got != 2 {
+		t.Errorf("last(102) = %d, want 2", got)
+	}
+}
+
+func TestGeneratorRateIgnoresNewWithTime(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{t: start}
+	g, err := NewGenerator(WithClock(clock.Now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 1000 {
+		if _, err := g.New(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a backfill neither counts nor resets the window
+	if _, err := g.NewWithTime(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	clock.t = start.Add(time.Second)
+	if _, err := g.New(); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Rate(); got != 1000 {
+		t.Errorf("Rate() = %d, want 1000", got)
+	}
+}
+
+func TestGeneratorReadsClockOnce(t *testing.T) {
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	for _, policy := range []ClockPolicy{ClockAllow, ClockHold} {
+		clock := &fakeClock{t: start}
+		g, err := NewDeterministic(1, func() time.Time {
+			clock.t = clock.t.Add(time.Second)
+			return clock.t
+		}, WithClockPolicy(policy))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i <= 3; i++ {
+			id, err := g.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := id.Time(), start.Add(time.Duration(i)*time.Second); !got.Equal(want) {
+				t.Errorf("policy%d: ID %d time = %v, want %v", policy, i, got.UTC(), want)
+			}
+		}
+	}
+}
+
+func TestGeneratorPublishExpvar(t *testing.T) {
+	g, err := NewGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if _, err := g.New(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.PublishExpvar("rid_test")
+	var got map[string]uint64
+	if err := json.Unmarshal([]byte(expvar.Get("rid_test").String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["issued"] != 3 || len(got) != 4 {
+		t.Errorf("expvar = %v, want 4 counters with issued 3", got)
+	}
+}
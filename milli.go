@@ -0,0 +1,211 @@
+package rid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MilliID is a variant of ID for applications needing sub-second ordering.
+// It shares the 10-byte binary and 16-character Base32 representations of ID
+// but is comprised of:
+//
+//   - 6-byte timestamp value representing milliseconds since the Unix epoch
+//   - 4-byte random value
+//
+// With only 32 random bits per millisecond MilliID trades some uniqueness for
+// ordering; the encoded forms of ID and MilliID are indistinguishable, so a
+// column or field should hold only one or the other.
+type MilliID [rawLen]byte
+
+// nilMilliID represents the zero-value of a MilliID
+var nilMilliID MilliID
+
+// NewMilli returns a new MilliID using the current time.
+func NewMilli() MilliID {
+	return NewMilliWithTime(time.Now())
+}
+
+// NewMilliWithTime returns a new MilliID using the supplied time, truncated to
+// milliseconds.
+func NewMilliWithTime(t time.Time) MilliID {
+	var id MilliID
+
+	_ = id[9]                   // bounds check hint to compiler
+	ms := uint64(t.UnixMilli()) // 6 bytes of time, milliseconds resolution
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	id[2] = byte(ms >> 24)
+	id[3] = byte(ms >> 16)
+	id[4] = byte(ms >> 8)
+	id[5] = byte(ms)
+	rand.Read(id[6:])
+
+	return id
+}
+
+// IsNil returns true if id == the zero value.
+func (id MilliID) IsNil() bool {
+	return id == nilMilliID
+}
+
+// IsZero is an alias of IsNil.
+func (id MilliID) IsZero() bool {
+	return id.IsNil()
+}
+
+// String returns id as Base32 encoded string using the package character set.
+func (id MilliID) String() string {
+	text := make([]byte, encodedLen)
+	encode(text, id[:])
+	return string(text)
+}
+
+// Encode id, writing 16 bytes to dst and returning it.
+func (id MilliID) Encode(dst []byte) []byte {
+	encode(dst, id[:])
+	return dst
+}
+
+// SortableString returns id Base32 encoded with the sort-preserving
+// character set; see ID.SortableString.
+func (id MilliID) SortableString() string {
+	text := make([]byte, encodedLen)
+	encodeWith(text, id[:], sortCharset)
+	return string(text)
+}
+
+// Bytes returns the binary representation of id.
+func (id MilliID) Bytes() []byte {
+	return id[:]
+}
+
+// Timestamp returns the timestamp component of id as milliseconds since the
+// Unix epoch.
+func (id MilliID) Timestamp() int64 {
+	b := id[0:6]
+	// Big Endian
+	return int64(uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5]))
+}
+
+// Time returns the timestamp of id as a Time value.
+func (id MilliID) Time() time.Time {
+	return time.UnixMilli(id.Timestamp())
+}
+
+// Random returns the random component of id.
+func (id MilliID) Random() uint32 {
+	b := id[6:]
+	// Big Endian
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// MilliFromString decodes a Base32-encoded string to return a MilliID.
+func MilliFromString(str string) (MilliID, error) {
+	id := &MilliID{}
+	err := id.UnmarshalText([]byte(str))
+
+	return *id, err
+}
+
+// MilliFromSortableString decodes a string produced by SortableString to
+// return a MilliID.
+func MilliFromSortableString(str string) (MilliID, error) {
+	id := &MilliID{}
+	err := unmarshalText((*ID)(id), []byte(str), sortCharset, &sortDec)
+
+	return *id, err
+}
+
+// MilliFromBytes copies []bytes into a MilliID value. For validity, only a
+// length-check is possible and performed.
+func MilliFromBytes(b []byte) (MilliID, error) {
+	var id MilliID
+
+	if len(b) != rawLen {
+		return nilMilliID, ErrInvalidID
+	}
+
+	copy(id[:], b)
+
+	return id, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *MilliID) UnmarshalText(text []byte) error {
+	return unmarshalText((*ID)(id), text, charset, &dec)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id MilliID) MarshalText() ([]byte, error) {
+	text := make([]byte, encodedLen)
+	encode(text, id[:])
+
+	return text, nil
+}
+
+// Value implements package sql's driver.Valuer.
+func (id MilliID) Value() (driver.Value, error) {
+	if id.IsNil() {
+		return nil, nil
+	}
+
+	b, err := id.MarshalText()
+
+	return string(b), err
+}
+
+// Scan implements the sql.Scanner interface.
+func (id *MilliID) Scan(value interface{}) (err error) {
+	switch val := value.(type) {
+	case string:
+		return id.UnmarshalText([]byte(val))
+	case []byte:
+		return id.UnmarshalText(val)
+	case nil:
+		*id = nilMilliID
+		return nil
+	default:
+		return fmt.Errorf("rid: scanning unsupported type: %T", value)
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (id MilliID) MarshalJSON() ([]byte, error) {
+	return ID(id).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (id *MilliID) UnmarshalJSON(b []byte) error {
+	return (*ID)(id).UnmarshalJSON(b)
+}
+
+// Compare returns an integer comparing only the 6-byte millisecond timestamps
+// of two MilliIDs. The result will be 0 if both were generated in the same
+// millisecond, -1 if id is earlier than other, and 1 if id is later.
+func (id MilliID) Compare(other MilliID) int {
+	return bytes.Compare(id[:6], other[:6])
+}
+
+type milliSorter []MilliID
+
+func (s milliSorter) Len() int {
+	return len(s)
+}
+
+func (s milliSorter) Less(i, j int) bool {
+	return s[i].Compare(s[j]) < 0
+}
+
+func (s milliSorter) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// SortMilli sorts a slice of MilliIDs in place.
+func SortMilli(ids []MilliID) {
+	sort.Sort(milliSorter(ids))
+}
@@ -0,0 +1,126 @@
+package rid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewMilliWithTime(t *testing.T) {
+	ts := time.Date(2024, time.March, 1, 12, 30, 45, 123456789, time.UTC)
+	id := NewMilliWithTime(ts)
+	if got, want := id.Timestamp(), ts.UnixMilli(); got != want {
+		t.Errorf("Timestamp() = %v, want %v", got, want)
+	}
+	if got, want := id.Time(), ts.Truncate(time.Millisecond); !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+}
+
+func TestMilliIDParts(t *testing.T) {
+	// ts:1740000123456 rnd:4294967295
+	id := MilliID{0x01, 0x95, 0x20, 0x16, 0xda, 0x40, 0xff, 0xff, 0xff, 0xff}
+	if got, want := id.Timestamp(), int64(1740000123456); got != want {
+		t.Errorf("Timestamp() = %v, want %v", got, want)
+	}
+	if got, want := id.Random(), uint32(0xffffffff); got != want {
+		t.Errorf("Random() = %v, want %v", got, want)
+	}
+	got, err := MilliFromString(id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("MilliFromString(%s) = %v, want %v", id, got, id)
+	}
+	if _, err := MilliFromString("012345"); err != ErrInvalidID {
+		t.Errorf("MilliFromString(invalid length) err=%v, want %v", err, ErrInvalidID)
+	}
+	sorted, err := MilliFromSortableString(id.SortableString())
+	if err != nil || sorted != id {
+		t.Errorf("MilliFromSortableString(%s) = %v, %v, want %v", id.SortableString(), sorted, err, id)
+	}
+	if _, err := MilliFromSortableString(id.String()[:15] + "a"); err != ErrInvalidID {
+		t.Errorf("MilliFromSortableString(invalid) err=%v, want %v", err, ErrInvalidID)
+	}
+	b, err := MilliFromBytes(id.Bytes())
+	if err != nil || b != id {
+		t.Errorf("MilliFromBytes() = %v, %v, want %v", b, err, id)
+	}
+	if _, err := MilliFromBytes([]byte{0x1}); err != ErrInvalidID {
+		t.Errorf("MilliFromBytes(invalid) err=%v, want %v", err, ErrInvalidID)
+	}
+}
+
+func TestMilliIDOrdering(t *testing.T) {
+	base := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ids := make([]MilliID, 0, 10)
+	for i := 9; i >= 0; i-- {
+		ids = append(ids, NewMilliWithTime(base.Add(time.Duration(i)*time.Millisecond)))
+	}
+	SortMilli(ids)
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1].Compare(ids[i]) != -1 {
+			t.Errorf("ids[%d] %v not before ids[%d] %v", i-1, ids[i-1].Time(), i, ids[i].Time())
+		}
+		if ids[i-1].SortableString() >= ids[i].SortableString() {
+			t.Errorf("SortableString() out of order at %d", i)
+		}
+	}
+	if got := ids[0].Compare(ids[0]); got != 0 {
+		t.Errorf("Compare(self) = %d, want 0", got)
+	}
+}
+
+func TestMilliIDJSON(t *testing.T) {
+	type v struct {
+		ID MilliID
+	}
+	in := v{NewMilli()}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out v
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("json round trip = %v, want %v", out, in)
+	}
+	data, err = json.Marshal(v{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"ID":null}`; got != want {
+		t.Errorf("json.Marshal(nil) = %v, want %v", got, want)
+	}
+}
+
+func TestMilliIDDriver(t *testing.T) {
+	id := NewMilli()
+	val, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got MilliID
+	if err := got.Scan(val); err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("Scan(Value()) = %v, want %v", got, id)
+	}
+	if err := got.Scan([]byte(id.String())); err != nil || got != id {
+		t.Errorf("Scan([]byte) = %v, %v, want %v", got, err, id)
+	}
+	if err := got.Scan(nil); err != nil || !got.IsZero() {
+		t.Errorf("Scan(nil) = %v, %v, want nil id", got, err)
+	}
+	if val, _ := got.Value(); val != nil {
+		t.Errorf("Value() of nil id = %v, want nil", val)
+	}
+	if err := got.Scan(1); err == nil {
+		t.Error("Scan(int) want error")
+	}
+}
@@ -0,0 +1,34 @@
+package rid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromName(t *testing.T) {
+	ns := IDs[0].id
+	ts := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	got := FromName(ns, []byte("customers/1234"), ts)
+	// random field computed independently with Python's hmac module
+	want := ID{0x5e, 0x0b, 0xe1, 0x00, 0xd5, 0x47, 0xd2, 0xa4, 0x9a, 0x2b}
+	if got != want {
+		t.Errorf("FromName() = %#v, want %#v", got, want)
+	}
+	if again := FromName(ns, []byte("customers/1234"), ts.Add(500*time.Millisecond)); again != got {
+		t.Errorf("FromName() not idempotent within a second: %v, %v", again, got)
+	}
+	if !got.Time().Equal(ts) {
+		t.Errorf("Time() = %v, want %v", got.Time(), ts)
+	}
+	for name, other := range map[string]ID{
+		"name":      FromName(ns, []byte("customers/1235"), ts),
+		"namespace": FromName(IDs[5].id, []byte("customers/1234"), ts),
+	} {
+		if other.Random() == got.Random() {
+			t.Errorf("different %s, same random field %v", name, other)
+		}
+	}
+	if later := FromName(ns, []byte("customers/1234"), ts.Add(time.Hour)); later.Random() != got.Random() {
+		t.Errorf("random field depends on time: %v, %v", later, got)
+	}
+}
@@ -0,0 +1,111 @@
+package rid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// maxNodeBits is the widest node identifier permitted, leaving at least 16
+// random bits per ID.
+const maxNodeBits = 32
+
+// machineIDPaths are consulted in order by NodeFromMachineID.
+var machineIDPaths = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+// WithNode reserves the high bits of the 6-byte random field of each ID for
+// a node or shard identifier, giving hosts generating IDs in the same second
+// disjoint ID spaces at the cost of random bits. bits must be between 1 and
+// 32 and node must fit within them.
+func WithNode(node uint32, bits int) Option {
+	return func(g *Generator) error {
+		if bits < 1 || bits > maxNodeBits {
+			return fmt.Errorf("rid: node bits %d not within 1 and %d", bits, maxNodeBits)
+		}
+		if uint64(node) >= 1<<bits {
+			return fmt.Errorf("rid: node %d does not fit in %d bits", node, bits)
+		}
+		g.node, g.nodeBits = node, bits
+		return nil
+	}
+}
+
+// NodeFromMachineID derives a node identifier of the given width by hashing
+// the host's machine ID, as found in /etc/machine-id on most Linux systems.
+// Distinct hosts may collide; assign nodes explicitly where that matters.
+func NodeFromMachineID(bits int) (uint32, error) {
+	if bits < 1 || bits > maxNodeBits {
+		return 0, fmt.Errorf("rid: node bits %d not within 1 and %d", bits, maxNodeBits)
+	}
+	for _, path := range machineIDPaths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if b = bytes.TrimSpace(b); len(b) > 0 {
+			sum := sha256.Sum256(b)
+			n := uint32(sum[0])<<24 | uint32(sum[1])<<16 | uint32(sum[2])<<8 | uint32(sum[3])
+			return n >> (32 - bits), nil
+		}
+	}
+
+	return 0, errors.New("rid: no machine id found")
+}
+
+// NodeFromEnv parses a node identifier of the given width from the
+// environment variable name, for example one set per pod or shard.
+func NodeFromEnv(name string, bits int) (uint32, error) {
+	if bits < 1 || bits > maxNodeBits {
+		return 0, fmt.Errorf("rid: node bits %d not within 1 and %d", bits, maxNodeBits)
+	}
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return 0, fmt.Errorf("rid: environment variable %s not set", name)
+	}
+	n, err := strconv.ParseUint(v, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("rid: node from %s: %w", name, err)
+	}
+
+	return uint32(n), nil
+}
+
+// Node returns the node identifier stored in the high bits of the random
+// field of id, for IDs made by a Generator configured WithNode(_, bits).
+func (id ID) Node(bits int) uint32 {
+	if bits < 1 || bits > maxNodeBits {
+		return 0
+	}
+	return uint32(id.Random() >> (48 - bits))
+}
+
+// Node returns the node identifier stored in id, zero if the generator has
+// no node configured.
+func (g *Generator) Node(id ID) uint32 {
+	return id.Node(g.nodeBits)
+}
+
+// NodeBits returns the number of random bits reserved for the node
+// identifier.
+func (g *Generator) NodeBits() int {
+	return g.nodeBits
+}
+
+// setNode overwrites the high bits of the random field of id with the
+// generator's node identifier.
+func (g *Generator) setNode(id *ID) {
+	if g.nodeBits == 0 {
+		return
+	}
+	shift := 48 - g.nodeBits
+	r := id.Random()&(1<<shift-1) | uint64(g.node)<<shift
+	id[4] = byte(r >> 40)
+	id[5] = byte(r >> 32)
+	id[6] = byte(r >> 24)
+	id[7] = byte(r >> 16)
+	id[8] = byte(r >> 8)
+	id[9] = byte(r)
+}
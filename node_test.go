@@ -0,0 +1,129 @@
+package rid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGeneratorNode(t *testing.T) {
+	tests := []struct {
+		node uint32
+		bits int
+	}{
+		{1, 1},
+		{0, 8},
+		{0xab, 8},
+		{1023, 10},
+		{0xffffffff, 32},
+	}
+	for _, tt := range tests {
+		g, err := NewGenerator(WithNode(tt.node, tt.bits))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.NodeBits(); got != tt.bits {
+			t.Errorf("NodeBits() = %d, want %d", got, tt.bits)
+		}
+		ts := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+		for range 100 {
+			id, err := g.NewWithTime(ts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := id.Node(tt.bits); got != tt.node {
+				t.Fatalf("ID.Node(%d) = %d, want %d", tt.bits, got, tt.node)
+			}
+			if got := g.Node(id); got != tt.node {
+				t.Fatalf("Generator.Node() = %d, want %d", got, tt.node)
+			}
+			if got := id.Time(); !got.Equal(ts) {
+				t.Fatalf("Time() = %v, want %v", got, ts)
+			}
+		}
+	}
+}
+
+func TestGeneratorNodeKeepsRandomBits(t *testing.T) {
+	g, err := NewGenerator(WithNode(0, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seen uint64
+	for range 100 {
+		id, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen |= id.Random()
+	}
+	if got, want := seen, uint64(1<<40-1); got != want {
+		t.Errorf("random bits used = %#x, want %#x", got, want)
+	}
+}
+
+func TestWithNodeErrors(t *testing.T) {
+	for _, tt := range []struct {
+		node uint32
+		bits int
+	}{
+		{0, 0},
+		{0, 33},
+		{2, 1},
+		{256, 8},
+	} {
+		if _, err := NewGenerator(WithNode(tt.node, tt.bits)); err == nil {
+			t.Errorf("WithNode(%d, %d) want error", tt.node, tt.bits)
+		}
+	}
+	if got := (ID{}).Node(0); got != 0 {
+		t.Errorf("ID.Node(0) = %d, want 0", got)
+	}
+}
+
+func TestNodeFromEnv(t *testing.T) {
+	t.Setenv("RID_TEST_NODE", "42")
+	if got, err := NodeFromEnv("RID_TEST_NODE", 8); err != nil || got != 42 {
+		t.Errorf("NodeFromEnv() = %d, %v, want 42", got, err)
+	}
+	if _, err := NodeFromEnv("RID_TEST_NODE", 4); err == nil {
+		t.Error("NodeFromEnv(42, 4 bits) want error")
+	}
+	t.Setenv("RID_TEST_NODE", "shard")
+	if _, err := NodeFromEnv("RID_TEST_NODE", 8); err == nil {
+		t.Error("NodeFromEnv(shard) want error")
+	}
+	if _, err := NodeFromEnv("RID_TEST_NODE_UNSET", 8); err == nil {
+		t.Error("NodeFromEnv(unset) want error")
+	}
+}
+
+func TestNodeFromMachineID(t *testing.T) {
+	saved := machineIDPaths
+	defer func() { machineIDPaths = saved }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "machine-id")
+	if err := os.WriteFile(path, []byte("4c4c4544004e3610804cb2c04f4d3332\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	machineIDPaths = []string{filepath.Join(dir, "missing"), path}
+	a, err := NodeFromMachineID(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a >= 1<<12 {
+		t.Errorf("NodeFromMachineID(12) = %d, does not fit", a)
+	}
+	if b, _ := NodeFromMachineID(12); a != b {
+		t.Errorf("NodeFromMachineID() not stable: %d, %d", a, b)
+	}
+	machineIDPaths = []string{filepath.Join(dir, "missing")}
+	if _, err := NodeFromMachineID(12); err == nil {
+		t.Error("NodeFromMachineID() with no machine id want error")
+	}
+	if _, err := NodeFromMachineID(0); err == nil {
+		t.Error("NodeFromMachineID(0) want error")
+	}
+}
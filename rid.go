@@ -17,7 +17,8 @@ The 10-byte binary representation of an ID is comprised of:
 
 Key features:
 
-  - K-orderable in both binary and string representations
+  - K-orderable in both binary and string representations; SortableString
+    guarantees string order matches binary order exactly
   - Encoded IDs are short (16 characters)
   - Automatic (de)serialization for SQL and JSON
   - Scalable performance as cores increase; ID generation is fast and remains so
@@ -41,6 +42,7 @@ package rid
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/subtle"
 	"database/sql/driver"
 	"errors"
 	"fmt"
@@ -56,6 +58,9 @@ const (
 	encodedLen = 16                                 // base32
 	charset    = "0123456789bcdefghkjlmnpqrstvwxyz" // fewer vowels to avoid random rudeness
 	maxByte    = 0xFF                               // used as a sentinel value in charmap
+
+	// sortCharset is charset in ascending ASCII order; only k and j trade places.
+	sortCharset = "0123456789bcdefghjklmnpqrstvwxyz"
 )
 
 var (
@@ -65,6 +70,9 @@ var (
 	// dec provides a decoding map
 	dec [256]byte
 
+	// sortDec provides a decoding map for the sort-preserving encoding
+	sortDec [256]byte
+
 	// ErrInvalidID represents errors returned when converting from invalid
 	// []byte, string or json representations
 	ErrInvalidID = errors.New("rid: invalid id")
@@ -74,9 +82,11 @@ func init() {
 	// initialize the decoding map, used also for sanity checking input
 	for i := range len(dec) {
 		dec[i] = maxByte
+		sortDec[i] = maxByte
 	}
 	for i := range len(charset) {
 		dec[charset[i]] = byte(i)
+		sortDec[sortCharset[i]] = byte(i)
 	}
 }
 
@@ -89,6 +99,8 @@ func New() ID {
 //
 // The time value component of an ID is a Unix timestamp with seconds
 // resolution; Go timestamp values reflect UTC and are not location aware.
+// Times before 1970 or after 2106 wrap silently; use a Generator to have
+// them rejected or to set a custom epoch.
 func NewWithTime(t time.Time) ID {
 	var id ID
 
@@ -113,6 +125,13 @@ func (id ID) IsZero() bool {
 	return id.IsNil()
 }
 
+// EqualConstantTime reports whether id and other are equal, taking time
+// independent of their contents. Use it in place of == where IDs act as
+// bearer tokens, such as in password reset links.
+func (id ID) EqualConstantTime(other ID) bool {
+	return subtle.ConstantTimeCompare(id[:], other[:]) == 1
+}
+
 // NilID returns a zero value for `rid.ID`.
 func NilID() ID {
 	return nilID
@@ -131,9 +150,37 @@ func (id ID) Encode(dst []byte) []byte {
 	return dst
 }
 
-// encode bytes as Base32, unrolling the stdlib base32 algorithm for
-// performance. There is no padding as Base32 aligns on 5-byte boundaries.
+// SortableString returns id as a Base32 encoded string using a variant of
+// the character set arranged in ascending ASCII order. Unlike String, the
+// result sorts lexicographically exactly as the binary ID does:
+//
+//	a.SortableString() < b.SortableString() iff bytes.Compare(a[:], b[:]) < 0
+//
+// This makes it suitable for text keys in stores relying on range scans.
+// The two encodings differ only in the meaning of 'j' and 'k' and are not
+// interchangeable; decode with FromSortableString.
+func (id ID) SortableString() string {
+	text := make([]byte, encodedLen)
+	encodeWith(text, id[:], sortCharset)
+	return string(text)
+}
+
+// EncodeSortable id using the sort-preserving encoding, writing 16 bytes to
+// dst and returning it.
+func (id ID) EncodeSortable(dst []byte) []byte {
+	encodeWith(dst, id[:], sortCharset)
+	return dst
+}
+
+// encode bytes as Base32 using charset.
 func encode(dst, id []byte) {
+	encodeWith(dst, id, charset)
+}
+
+// encodeWith encodes bytes as Base32 using the supplied alphabet, unrolling
+// the stdlib base32 algorithm for performance. There is no padding as Base32
+// aligns on 5-byte boundaries.
+func encodeWith(dst, id []byte, charset string) {
 	_ = id[9] // bounds checks
 	_ = dst[15]
 
@@ -201,38 +248,55 @@ func FromBytes(b []byte) (ID, error) {
 	return id, nil
 }
 
+// FromSortableString decodes a string produced by SortableString to return
+// an ID.
+func FromSortableString(str string) (ID, error) {
+	id := &ID{}
+	err := unmarshalText(id, []byte(str), sortCharset, &sortDec)
+
+	return *id, err
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler
 // https://golang.org/pkg/encoding/#TextUnmarshaler
 // All decoding is called from here.
 func (id *ID) UnmarshalText(text []byte) error {
+	return unmarshalText(id, text, charset, &dec)
+}
+
+// unmarshalText decodes text into id using the supplied alphabet and its
+// decoding map.
+func unmarshalText(id *ID, text []byte, charset string, dec *[256]byte) error {
 	if len(text) != encodedLen {
 		*id = nilID
 		return ErrInvalidID
 	}
-	// characters not in the decoding map will return an error
+	// characters not in the decoding map will return an error; valid values
+	// are < 32, so every character is checked without branching, and timing
+	// doesn't reveal the position of the first invalid one
+	var invalid byte
 	for _, c := range text {
-		if dec[c] == maxByte {
-			return ErrInvalidID
-		}
+		invalid |= dec[c] &^ 0x1F
 	}
 
-	if !decode(id, text) {
+	var decoded ID
+	if !decode(&decoded, text, charset, dec) || invalid != 0 {
 		*id = nilID
 		return ErrInvalidID
 	}
+	*id = decoded
 
 	return nil
 }
 
 // decode a Base32 encoded string by unrolling the stdlib Base32 algorithm.
-func decode(id *ID, src []byte) bool {
+func decode(id *ID, src []byte, charset string, dec *[256]byte) bool {
 	_ = src[15] // bounds check
 	// this is ~4 to 6x faster than stdlib Base32 decoding
 	id[9] = dec[src[14]]<<5 | dec[src[15]]
-	// check the last byte
-	if charset[id[9]&0x1F] != src[15] {
-		return false
-	}
+	// check the last byte, deferring the result so decoding always runs
+	// to completion
+	ok := charset[id[9]&0x1F] == src[15]
 	id[8] = dec[src[12]]<<7 | dec[src[13]]<<2 | dec[src[14]]>>3
 	id[7] = dec[src[11]]<<4 | dec[src[12]]>>1
 	id[6] = dec[src[9]]<<6 | dec[src[10]]<<1 | dec[src[11]]>>4
@@ -243,7 +307,27 @@ func decode(id *ID, src []byte) bool {
 	id[1] = dec[src[1]]<<6 | dec[src[2]]<<1 | dec[src[3]]>>4
 	id[0] = dec[src[0]]<<3 | dec[src[1]]>>2
 
-	return true
+	return ok
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the 10-byte
+// binary representation of id.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, rawLen))
+}
+
+// AppendBinary implements encoding.BinaryAppender.
+func (id ID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler; as for FromBytes,
+// only a length-check is possible and performed.
+func (id *ID) UnmarshalBinary(b []byte) error {
+	v, err := FromBytes(b)
+	*id = v
+
+	return err
 }
 
 // MarshalText implements encoding.TextMarshaler.
@@ -8,7 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"testing/quick"
 	"time"
 )
 
@@ -230,6 +232,39 @@ func TestID_UnmarshalTextError(t *testing.T) {
 	}
 }
 
+func TestID_UnmarshalTextInvalidPosition(t *testing.T) {
+	// an invalid character anywhere is caught, and leaves a nil ID
+	valid := []byte("dfp7emzzzzy30ey2")
+	for i := range valid {
+		text := append([]byte(nil), valid...)
+		text[i] = 'u'
+		id := New()
+		if err := id.UnmarshalText(text); err != ErrInvalidID {
+			t.Errorf("UnmarshalText(%s) err = %v, want %v", text, err, ErrInvalidID)
+		}
+		if id != nilID {
+			t.Errorf("UnmarshalText(%s) id = %v, want nil ID", text, id)
+		}
+	}
+}
+
+func TestID_EqualConstantTime(t *testing.T) {
+	a := IDs[0].id
+	if !a.EqualConstantTime(a) {
+		t.Error("EqualConstantTime(self) = false")
+	}
+	for i := range rawLen {
+		b := a
+		b[i] ^= 0x01
+		if a.EqualConstantTime(b) {
+			t.Errorf("EqualConstantTime() = true for IDs differing at byte %d", i)
+		}
+	}
+	if !NilID().EqualConstantTime(ID{}) {
+		t.Error("NilID().EqualConstantTime(ID{}) = false")
+	}
+}
+
 func TestID_IsNil(t *testing.T) {
 	tests := []struct {
 		name string
@@ -297,6 +332,28 @@ func TestFromBytes_Invariant(t *testing.T) {
 	}
 }
 
+func TestIDBinaryMarshaling(t *testing.T) {
+	id := IDs[0].id
+	b, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, id[:]) {
+		t.Errorf("MarshalBinary() = %v, want %v", b, id[:])
+	}
+	prefix := []byte{0x1}
+	if b, _ := id.AppendBinary(prefix); !bytes.Equal(b, append(prefix, id[:]...)) {
+		t.Errorf("AppendBinary() = %v", b)
+	}
+	var got ID
+	if err := got.UnmarshalBinary(b); err != nil || got != id {
+		t.Errorf("UnmarshalBinary() = %v, %v, want %v", got, err, id)
+	}
+	if err := got.UnmarshalBinary(b[:5]); err != ErrInvalidID || got != nilID {
+		t.Errorf("UnmarshalBinary(short) = %v, %v, want nil ID, %v", got, err, ErrInvalidID)
+	}
+}
+
 type jsonType struct {
 	ID  *ID
 	Str string
@@ -504,7 +561,64 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSortableString(t *testing.T) {
+	for _, v := range IDs {
+		s := v.id.SortableString()
+		got, err := FromSortableString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v.id {
+			t.Errorf("FromSortableString(%s) = %v, want %v", s, got, v.id)
+		}
+		text := make([]byte, encodedLen)
+		if got, want := string(v.id.EncodeSortable(text)), s; got != want {
+			t.Errorf("EncodeSortable() = %v, want %v", got, want)
+		}
+	}
+	// the default encoding maps 17 to 'k' and 18 to 'j', breaking string order
+	a, b := ID{0, 0, 0, 0, 0, 0, 0, 0, 0, 17}, ID{0, 0, 0, 0, 0, 0, 0, 0, 0, 18}
+	if a.String() < b.String() {
+		t.Errorf("String() expected to disagree with binary order for %v, %v", a, b)
+	}
+	if a.SortableString() >= b.SortableString() {
+		t.Errorf("SortableString() %s >= %s", a.SortableString(), b.SortableString())
+	}
+	if _, err := FromSortableString("dfp7em"); err != ErrInvalidID {
+		t.Errorf("FromSortableString(invalid length) err=%v, want %v", err, ErrInvalidID)
+	}
+}
+
+func TestSortableStringOrder(t *testing.T) {
+	sign := func(i int) int {
+		switch {
+		case i < 0:
+			return -1
+		case i > 0:
+			return 1
+		}
+		return 0
+	}
+	f := func(a, b ID) bool {
+		return sign(strings.Compare(a.SortableString(), b.SortableString())) ==
+			sign(bytes.Compare(a[:], b[:]))
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 100000}); err != nil {
+		t.Error(err)
+	}
+	// IDs sharing a prefix exercise the later characters
+	g := func(a ID, i uint8, b byte) bool {
+		c := a
+		c[int(i)%rawLen] = b
+		return f(a, c)
+	}
+	if err := quick.Check(g, &quick.Config{MaxCount: 100000}); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestFastrand48New(t *testing.T) {
+	// exercises the package level random source, so it cannot be seeded
 	t.Run("check-dupes", func(t *testing.T) {
 		// see eval/uniqcheck/main.go for proof of utility testing in concurrent environments
 		var id ID
@@ -578,6 +692,8 @@ func BenchmarkFromString(b *testing.B) {
 }
 
 // examples
+// New draws from crypto/rand, so this example has no Output; see
+// ExampleGenerator_New for the same fields from a seeded generator.
 func ExampleNew() {
 	id := New()
 	fmt.Printf(`ID:
@@ -588,6 +704,32 @@ func ExampleNew() {
     Bytes()   %3v\n`, id.String(), id.Timestamp(), id.Random(), id.Time().UTC(), id.Bytes())
 }
 
+func ExampleGenerator_New() {
+	clock := func() time.Time { return time.Date(2022, time.December, 28, 17, 3, 15, 0, time.UTC) }
+	g, err := NewDeterministic(1, clock) // reproducible; not for production
+	if err != nil {
+		panic(err)
+	}
+	id, err := g.New()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf(`ID:
+    String()    %s
+    Timestamp() %d
+    Random()    %d
+    Time()      %v
+    Bytes()     %3v
+`, id.String(), id.Timestamp(), id.Random(), id.Time().UTC(), id.Bytes())
+	// Output:
+	// ID:
+	//     String()    dfp7emvbwsw3ylxx
+	//     Timestamp() 1672246995
+	//     Random()    117538092437437
+	//     Time()      2022-12-28 17:03:15 +0000 UTC
+	//     Bytes()     [ 99 172 118 211 106 230 120  63  79 189]
+}
+
 func ExampleNewWithTime() {
 	id := NewWithTime(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
 	fmt.Printf(`ID: Timestamp() %d Time() %v`, id.Timestamp(), id.Time().UTC())
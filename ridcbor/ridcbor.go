@@ -0,0 +1,146 @@
+/*
+Package ridcbor encodes and decodes rid IDs in CBOR (RFC 8949) without
+dependencies. IDs are written as a 10-byte byte string, optionally enclosed in
+an application tag, and decoding accepts the byte string and 16-character
+text string forms, so producers can migrate from strings gradually. Null and
+undefined decode as a nil ID. Only definite-length items are supported.
+*/
+package ridcbor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mwyvr/rid"
+)
+
+// ErrMalformed is returned for truncated data or items of other types.
+var ErrMalformed = errors.New("ridcbor: malformed data")
+
+const (
+	rawLen     = 10
+	encodedLen = 16
+
+	majorBytes = 2
+	majorText  = 3
+	majorTag   = 6
+
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+)
+
+// AppendBytes appends id as a 10-byte byte string.
+func AppendBytes(b []byte, id rid.ID) []byte {
+	b = appendHead(b, majorBytes, rawLen)
+	b, _ = id.AppendBinary(b)
+
+	return b
+}
+
+// AppendText appends id in its Base32 form as a text string.
+func AppendText(b []byte, id rid.ID) []byte {
+	b = appendHead(b, majorText, encodedLen)
+	n := len(b)
+	b = append(b, make([]byte, encodedLen)...)
+	id.Encode(b[n:])
+
+	return b
+}
+
+// AppendTagged appends id as a byte string enclosed in the given tag.
+func AppendTagged(b []byte, tag uint64, id rid.ID) []byte {
+	return AppendBytes(appendHead(b, majorTag, tag), id)
+}
+
+// Decode parses an untagged ID from the start of b, returning it and the
+// number of bytes read.
+func Decode(b []byte) (rid.ID, int, error) {
+	if len(b) > 0 && (b[0] == cborNull || b[0] == cborUndefined) {
+		return rid.NilID(), 1, nil
+	}
+	major, size, hdr, err := readHead(b)
+	if err != nil {
+		return rid.NilID(), 0, err
+	}
+	if major != majorBytes && major != majorText {
+		return rid.NilID(), 0, fmt.Errorf("%w: unexpected major type %d", ErrMalformed, major)
+	}
+	if uint64(len(b)-hdr) < size {
+		return rid.NilID(), 0, ErrMalformed
+	}
+	v := b[hdr : hdr+int(size)]
+
+	var id rid.ID
+	if major == majorText {
+		err = id.UnmarshalText(v)
+	} else {
+		err = id.UnmarshalBinary(v)
+	}
+	if err != nil {
+		return rid.NilID(), 0, err
+	}
+
+	return id, hdr + int(size), nil
+}
+
+// DecodeTagged is as Decode, also accepting an ID enclosed in the given tag.
+// Other tags return ErrMalformed.
+func DecodeTagged(b []byte, tag uint64) (rid.ID, int, error) {
+	major, v, hdr, err := readHead(b)
+	if err != nil || major != majorTag {
+		return Decode(b)
+	}
+	if v != tag {
+		return rid.NilID(), 0, fmt.Errorf("%w: tag %d", ErrMalformed, v)
+	}
+	id, n, err := Decode(b[hdr:])
+	if err != nil {
+		return rid.NilID(), 0, err
+	}
+
+	return id, hdr + n, nil
+}
+
+// appendHead appends the head of an item of the given major type with
+// argument v, in its shortest form.
+func appendHead(b []byte, major byte, v uint64) []byte {
+	m := major << 5
+	switch {
+	case v < 24:
+		return append(b, m|byte(v))
+	case v <= 0xff:
+		return append(b, m|24, byte(v))
+	case v <= 0xffff:
+		return append(b, m|25, byte(v>>8), byte(v))
+	case v <= 0xffffffff:
+		return append(b, m|26, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return append(b, m|27, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+			byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+}
+
+// readHead parses the head at the start of b, returning the major type, its
+// argument and the head length.
+func readHead(b []byte) (major byte, v uint64, n int, err error) {
+	if len(b) == 0 {
+		return 0, 0, 0, ErrMalformed
+	}
+	major, info := b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, uint64(info), 1, nil
+	case info <= 27:
+		n = 1 << (info - 24) // 1, 2, 4 or 8 bytes follow
+	default:
+		return 0, 0, 0, fmt.Errorf("%w: indefinite length or reserved value %#x", ErrMalformed, b[0])
+	}
+	if len(b) < 1+n {
+		return 0, 0, 0, ErrMalformed
+	}
+	for _, c := range b[1 : 1+n] {
+		v = v<<8 | uint64(c)
+	}
+
+	return major, v, 1 + n, nil
+}
@@ -0,0 +1,94 @@
+package ridcbor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+// dfp7emzzzzy30ey2
+var testID = rid.ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want []byte
+	}{
+		{"bytes", AppendBytes(nil, testID), append([]byte{0x4a}, testID[:]...)},
+		{"text", AppendText(nil, testID), append([]byte{0x70}, "dfp7emzzzzy30ey2"...)},
+		{"tag 1-byte", AppendTagged(nil, 40, testID), append([]byte{0xd8, 0x28, 0x4a}, testID[:]...)},
+		{"tag 4-byte", AppendTagged(nil, 0x10000, testID), append([]byte{0xda, 0x00, 0x01, 0x00, 0x00, 0x4a}, testID[:]...)},
+		{"prefix kept", AppendBytes([]byte{0x82}, testID), append([]byte{0x82, 0x4a}, testID[:]...)},
+	}
+	for _, tt := range tests {
+		if !bytes.Equal(tt.got, tt.want) {
+			t.Errorf("%s: got % x, want % x", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for name, b := range map[string][]byte{
+		"bytes":       AppendBytes(nil, testID),
+		"bytes long":  append([]byte{0x59, 0x00, 0x0a}, testID[:]...),
+		"text":        AppendText(nil, testID),
+		"text 1-byte": append([]byte{0x78, 0x10}, "dfp7emzzzzy30ey2"...),
+	} {
+		// trailing data is left unread
+		id, n, err := Decode(append(b, 0xf6))
+		if err != nil {
+			t.Errorf("%s: Decode() err = %v", name, err)
+			continue
+		}
+		if id != testID || n != len(b) {
+			t.Errorf("%s: Decode() = %v, %d, want %v, %d", name, id, n, testID, len(b))
+		}
+	}
+	for _, b := range []byte{0xf6, 0xf7} {
+		if id, n, err := Decode([]byte{b}); err != nil || !id.IsNil() || n != 1 {
+			t.Errorf("Decode(%#x) = %v, %d, %v", b, id, n, err)
+		}
+	}
+}
+
+func TestDecodeTagged(t *testing.T) {
+	const tag = 0x10000
+	b := AppendTagged(nil, tag, testID)
+	if id, n, err := DecodeTagged(b, tag); err != nil || id != testID || n != len(b) {
+		t.Errorf("DecodeTagged() = %v, %d, %v", id, n, err)
+	}
+	// untagged items are accepted too
+	if id, _, err := DecodeTagged(AppendText(nil, testID), tag); err != nil || id != testID {
+		t.Errorf("DecodeTagged(untagged) = %v, %v", id, err)
+	}
+	if _, _, err := DecodeTagged(b, tag+1); !errors.Is(err, ErrMalformed) {
+		t.Errorf("DecodeTagged(other tag) err = %v, want %v", err, ErrMalformed)
+	}
+	if _, _, err := Decode(b); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Decode(tagged) err = %v, want %v", err, ErrMalformed)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		b    []byte
+		want error
+	}{
+		"empty":        {nil, ErrMalformed},
+		"int":          {[]byte{0x01}, ErrMalformed},
+		"truncated":    {AppendBytes(nil, testID)[:5], ErrMalformed},
+		"header only":  {[]byte{0x59, 0x00}, ErrMalformed},
+		"huge length":  {[]byte{0x5b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, ErrMalformed},
+		"indefinite":   {[]byte{0x5f, 0x4a}, ErrMalformed},
+		"short bytes":  {[]byte{0x42, 0x01, 0x02}, rid.ErrInvalidID},
+		"bad text":     {append([]byte{0x70}, "dfp7emzzzzy30eyu"...), rid.ErrInvalidID},
+		"short string": {append([]byte{0x63}, "abc"...), rid.ErrInvalidID},
+	} {
+		if _, _, err := Decode(tc.b); !errors.Is(err, tc.want) {
+			t.Errorf("%s: Decode(% x) err = %v, want %v", name, tc.b, err, tc.want)
+		}
+	}
+}
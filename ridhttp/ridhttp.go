@@ -0,0 +1,77 @@
+/*
+Package ridhttp provides net/http middleware that gives each request an ID.
+
+An ID supplied by the client in the request ID header is used if it decodes;
+otherwise a new one is generated. The ID is stored in the request context,
+where handlers retrieve it with FromContext or rid.FromContext, and echoed
+in the response header. An ID already carried by the request context, as set
+by outer middleware, becomes the new ID's parent; see rid.ChainFromContext.
+*/
+package ridhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mwyvr/rid"
+)
+
+// DefaultHeader is the header read and written by Middleware.
+const DefaultHeader = "X-Request-ID"
+
+// Option configures Handler.
+type Option func(*handler)
+
+// WithHeader sets the request and response header carrying the ID.
+func WithHeader(name string) Option {
+	return func(h *handler) {
+		h.header = http.CanonicalHeaderKey(name)
+	}
+}
+
+// WithValidateOptions rejects incoming IDs whose timestamps fall outside opts,
+// as rid.ParseStrict does; a new ID is generated in their place.
+func WithValidateOptions(opts rid.ValidateOptions) Option {
+	return func(h *handler) {
+		h.validate = &opts
+	}
+}
+
+type handler struct {
+	next     http.Handler
+	header   string
+	validate *rid.ValidateOptions
+}
+
+// Middleware wraps next with the default options.
+func Middleware(next http.Handler) http.Handler {
+	return Handler(next)
+}
+
+// Handler wraps next, assigning each request an ID as configured by opts.
+func Handler(next http.Handler, opts ...Option) http.Handler {
+	h := &handler{next: next, header: DefaultHeader}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := rid.FromString(r.Header.Get(h.header))
+	if err == nil && h.validate != nil {
+		err = id.Validate(*h.validate)
+	}
+	if err != nil || id.IsNil() {
+		id = rid.New()
+	}
+	w.Header().Set(h.header, id.String())
+	h.next.ServeHTTP(w, r.WithContext(rid.NewContext(r.Context(), id)))
+}
+
+// FromContext returns the request ID stored in ctx, and whether one was
+// found. It is equivalent to rid.FromContext.
+func FromContext(ctx context.Context) (rid.ID, bool) {
+	return rid.FromContext(ctx)
+}
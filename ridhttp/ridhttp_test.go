@@ -0,0 +1,102 @@
+package ridhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mwyvr/rid"
+)
+
+func serve(t *testing.T, h func(http.Handler) http.Handler, header, value string) (rid.ID, *httptest.ResponseRecorder) {
+	t.Helper()
+	var got rid.ID
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := FromContext(r.Context())
+		if !ok {
+			t.Error("FromContext() found no ID")
+		}
+		got = id
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	h(next).ServeHTTP(rec, req)
+
+	return got, rec
+}
+
+func TestMiddleware(t *testing.T) {
+	valid := "dfp7emzzzzy30ey2"
+	tests := []struct {
+		name     string
+		value    string
+		wantKeep bool
+	}{
+		{"absent", "", false},
+		{"valid", valid, true},
+		{"invalid", "dfp7emzzzzy30eyu", false},
+		{"too long", valid + "0", false},
+		{"nil", rid.NilID().String(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, rec := serve(t, Middleware, DefaultHeader, tt.value)
+			if id.IsNil() {
+				t.Fatal("request ID is nil")
+			}
+			if got := id.String() == tt.value; got != tt.wantKeep {
+				t.Errorf("kept incoming ID = %v, want %v", got, tt.wantKeep)
+			}
+			if got := rec.Header().Get(DefaultHeader); got != id.String() {
+				t.Errorf("response header = %q, want %q", got, id)
+			}
+		})
+	}
+}
+
+func TestHandlerOptions(t *testing.T) {
+	now := time.Now()
+	fresh, stale := rid.NewWithTime(now).String(), rid.NewWithTime(now.Add(-48*time.Hour)).String()
+	h := func(next http.Handler) http.Handler {
+		return Handler(next, WithHeader("x-correlation-id"),
+			WithValidateOptions(rid.ValidateOptions{MaxAge: 24 * time.Hour}))
+	}
+	id, rec := serve(t, h, "X-Correlation-Id", fresh)
+	if id.String() != fresh {
+		t.Errorf("fresh ID replaced with %v", id)
+	}
+	if got := rec.Header().Get("X-Correlation-Id"); got != fresh {
+		t.Errorf("response header = %q, want %q", got, fresh)
+	}
+	if rec.Header().Get(DefaultHeader) != "" {
+		t.Errorf("default header set with custom header configured")
+	}
+	if id, _ := serve(t, h, "X-Correlation-Id", stale); id.String() == stale {
+		t.Error("stale ID kept")
+	}
+}
+
+func TestFromContextMissing(t *testing.T) {
+	if id, ok := FromContext(context.Background()); ok || !id.IsNil() {
+		t.Errorf("FromContext() = %v, %v, want nil ID, false", id, ok)
+	}
+}
+
+func TestMiddlewareParent(t *testing.T) {
+	parent := rid.New()
+	var got rid.Chain
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = rid.ChainFromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(rid.NewContext(req.Context(), parent))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if got.Parent != parent || got.ID.IsNil() || got.ID == parent {
+		t.Errorf("ChainFromContext() = %+v, want new ID with parent %v", got, parent)
+	}
+}
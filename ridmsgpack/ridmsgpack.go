@@ -0,0 +1,127 @@
+/*
+Package ridmsgpack encodes and decodes rid IDs in MessagePack without
+dependencies. IDs are written as a 10-byte bin, or optionally as an extension
+type, and decoding accepts the binary, extension and 16-character string
+forms, so producers can migrate from strings gradually. A nil value decodes
+as a nil ID.
+*/
+package ridmsgpack
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mwyvr/rid"
+)
+
+// ExtType is the default application extension type for IDs, 'R'.
+const ExtType int8 = 0x52
+
+// ErrMalformed is returned for truncated data or values of other types.
+var ErrMalformed = errors.New("ridmsgpack: malformed data")
+
+const (
+	rawLen     = 10
+	encodedLen = 16
+
+	mpNil    = 0xc0
+	mpBin8   = 0xc4
+	mpBin16  = 0xc5
+	mpBin32  = 0xc6
+	mpExt8   = 0xc7
+	mpFixStr = 0xa0 // | length, up to 31
+	mpStr8   = 0xd9
+	mpStr16  = 0xda
+	mpStr32  = 0xdb
+)
+
+// AppendBin appends id as a 10-byte bin 8 value.
+func AppendBin(b []byte, id rid.ID) []byte {
+	b = append(b, mpBin8, rawLen)
+	b, _ = id.AppendBinary(b)
+
+	return b
+}
+
+// AppendExt appends id as an ext 8 value of the given application type.
+func AppendExt(b []byte, typ int8, id rid.ID) []byte {
+	b = append(b, mpExt8, rawLen, byte(typ))
+	b, _ = id.AppendBinary(b)
+
+	return b
+}
+
+// AppendStr appends id in its Base32 form as a fixstr value.
+func AppendStr(b []byte, id rid.ID) []byte {
+	b = append(b, mpFixStr|encodedLen)
+	n := len(b)
+	b = append(b, make([]byte, encodedLen)...)
+	id.Encode(b[n:])
+
+	return b
+}
+
+// Decode parses an ID from the start of b, returning it and the number of
+// bytes read. Extensions must be of ExtType.
+func Decode(b []byte) (rid.ID, int, error) {
+	return DecodeExt(b, ExtType)
+}
+
+// DecodeExt is as Decode, for IDs written with a custom extension type.
+func DecodeExt(b []byte, typ int8) (rid.ID, int, error) {
+	if len(b) == 0 {
+		return rid.NilID(), 0, ErrMalformed
+	}
+	var (
+		hdr  int // header length
+		size int // payload length
+		text bool
+	)
+	switch c := b[0]; {
+	case c == mpNil:
+		return rid.NilID(), 1, nil
+	case c == mpBin8, c == mpStr8:
+		hdr, size, text = 2, int(at(b, 1)), c == mpStr8
+	case c == mpBin16, c == mpStr16:
+		hdr, size, text = 3, int(at(b, 1))<<8|int(at(b, 2)), c == mpStr16
+	case c == mpBin32, c == mpStr32:
+		hdr, text = 5, c == mpStr32
+		size = int(at(b, 1))<<24 | int(at(b, 2))<<16 | int(at(b, 3))<<8 | int(at(b, 4))
+	case c&0xe0 == mpFixStr:
+		hdr, size, text = 1, int(c&0x1f), true
+	case c == mpExt8:
+		if len(b) > 2 && int8(b[2]) != typ {
+			return rid.NilID(), 0, fmt.Errorf("%w: extension type %d", ErrMalformed, int8(b[2]))
+		}
+		hdr, size = 3, int(at(b, 1))
+	default:
+		return rid.NilID(), 0, fmt.Errorf("%w: unexpected format %#x", ErrMalformed, c)
+	}
+	if len(b) < hdr || len(b)-hdr < size {
+		return rid.NilID(), 0, ErrMalformed
+	}
+	v := b[hdr : hdr+size]
+
+	var (
+		id  rid.ID
+		err error
+	)
+	if text {
+		err = id.UnmarshalText(v)
+	} else {
+		err = id.UnmarshalBinary(v)
+	}
+	if err != nil {
+		return rid.NilID(), 0, err
+	}
+
+	return id, hdr + size, nil
+}
+
+// at returns b[i], or zero if b is too short; lengths are checked after.
+func at(b []byte, i int) byte {
+	if i < len(b) {
+		return b[i]
+	}
+	return 0
+}
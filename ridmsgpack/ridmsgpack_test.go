@@ -0,0 +1,86 @@
+package ridmsgpack
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+// dfp7emzzzzy30ey2
+var testID = rid.ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want []byte
+	}{
+		{"bin", AppendBin(nil, testID), append([]byte{0xc4, 0x0a}, testID[:]...)},
+		{"ext", AppendExt(nil, ExtType, testID), append([]byte{0xc7, 0x0a, 0x52}, testID[:]...)},
+		{"str", AppendStr(nil, testID), append([]byte{0xb0}, "dfp7emzzzzy30ey2"...)},
+		{"prefix kept", AppendBin([]byte{0x92}, testID), append([]byte{0x92, 0xc4, 0x0a}, testID[:]...)},
+	}
+	for _, tt := range tests {
+		if !bytes.Equal(tt.got, tt.want) {
+			t.Errorf("%s: got % x, want % x", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	str := []byte("dfp7emzzzzy30ey2")
+	for name, b := range map[string][]byte{
+		"bin8":   AppendBin(nil, testID),
+		"bin16":  append([]byte{0xc5, 0x00, 0x0a}, testID[:]...),
+		"bin32":  append([]byte{0xc6, 0x00, 0x00, 0x00, 0x0a}, testID[:]...),
+		"ext":    AppendExt(nil, ExtType, testID),
+		"fixstr": AppendStr(nil, testID),
+		"str8":   append([]byte{0xd9, 0x10}, str...),
+		"str16":  append([]byte{0xda, 0x00, 0x10}, str...),
+		"str32":  append([]byte{0xdb, 0x00, 0x00, 0x00, 0x10}, str...),
+	} {
+		// trailing data is left unread
+		id, n, err := Decode(append(b, 0xc0))
+		if err != nil {
+			t.Errorf("%s: Decode() err = %v", name, err)
+			continue
+		}
+		if id != testID || n != len(b) {
+			t.Errorf("%s: Decode() = %v, %d, want %v, %d", name, id, n, testID, len(b))
+		}
+	}
+	if id, n, err := Decode([]byte{0xc0}); err != nil || !id.IsNil() || n != 1 {
+		t.Errorf("Decode(nil) = %v, %d, %v", id, n, err)
+	}
+}
+
+func TestDecodeExt(t *testing.T) {
+	b := AppendExt(nil, 7, testID)
+	if id, _, err := DecodeExt(b, 7); err != nil || id != testID {
+		t.Errorf("DecodeExt(7) = %v, %v", id, err)
+	}
+	if _, _, err := Decode(b); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Decode(ext 7) err = %v, want %v", err, ErrMalformed)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		b    []byte
+		want error
+	}{
+		"empty":        {nil, ErrMalformed},
+		"int":          {[]byte{0x01}, ErrMalformed},
+		"truncated":    {AppendBin(nil, testID)[:5], ErrMalformed},
+		"header only":  {[]byte{0xc5, 0x00}, ErrMalformed},
+		"short bin":    {[]byte{0xc4, 0x02, 0x01, 0x02}, rid.ErrInvalidID},
+		"bad text":     {append([]byte{0xb0}, "dfp7emzzzzy30eyu"...), rid.ErrInvalidID},
+		"short string": {append([]byte{0xa3}, "abc"...), rid.ErrInvalidID},
+	} {
+		if _, _, err := Decode(tc.b); !errors.Is(err, tc.want) {
+			t.Errorf("%s: Decode(% x) err = %v, want %v", name, tc.b, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Package ridpb provides Protocol Buffers helpers for rid IDs without depending
+on the protobuf module: conversions for IDs carried in bytes or string
+fields, protowire-style append and consume functions for hand-written
+encoders, and a well-known message shape:
+
+	syntax = "proto3";
+	package rid.v1;
+	message ID { bytes value = 1; }
+
+In a bytes field an ID is its 10-byte binary form; in a string field, its
+16-character Base32 form. A nil ID is the field's default, empty, value so
+that proto3 omits it.
+*/
+package ridpb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mwyvr/rid"
+)
+
+// Schema is the .proto definition of Message.
+const Schema = `syntax = "proto3";
+
+package rid.v1;
+
+// ID is a rid.ID in its 10-byte binary form.
+message ID {
+  bytes value = 1;
+}
+`
+
+// ErrMalformed is returned for truncated or otherwise invalid wire data.
+var ErrMalformed = errors.New("ridpb: malformed wire data")
+
+// Type is a protobuf wire type.
+type Type int8
+
+// Wire types; groups are not supported.
+const (
+	VarintType  Type = 0
+	Fixed64Type Type = 1
+	BytesType   Type = 2
+	Fixed32Type Type = 5
+)
+
+const (
+	encodedLen = 16 // Base32 form of an ID
+	maxField   = 1<<29 - 1
+)
+
+// ToBytes returns the value for a bytes field holding id: its binary form, or
+// nil for a nil ID.
+func ToBytes(id rid.ID) []byte {
+	if id.IsNil() {
+		return nil
+	}
+	b, _ := id.MarshalBinary()
+
+	return b
+}
+
+// FromBytes returns the ID held in a bytes field. An empty field is a nil
+// ID; any length other than 10 returns rid.ErrInvalidID.
+func FromBytes(b []byte) (rid.ID, error) {
+	if len(b) == 0 {
+		return rid.NilID(), nil
+	}
+
+	return rid.FromBytes(b)
+}
+
+// ToString returns the value for a string field holding id: its Base32 form,
+// or "" for a nil ID.
+func ToString(id rid.ID) string {
+	if id.IsNil() {
+		return ""
+	}
+
+	return id.String()
+}
+
+// FromString returns the ID held in a string field. An empty field is a nil
+// ID.
+func FromString(s string) (rid.ID, error) {
+	if s == "" {
+		return rid.NilID(), nil
+	}
+
+	return rid.FromString(s)
+}
+
+// Validate reports whether b, the payload of a length-delimited field, holds
+// a valid ID in binary or text form, or is empty.
+func Validate(b []byte) error {
+	_, err := decodeValue(b)
+	return err
+}
+
+// AppendTag appends a field tag to b.
+func AppendTag(b []byte, num int32, typ Type) []byte {
+	return AppendVarint(b, uint64(num)<<3|uint64(typ))
+}
+
+// AppendBytes appends id as a bytes field numbered num. Nil IDs are appended
+// too; omit the call to omit the field.
+func AppendBytes(b []byte, num int32, id rid.ID) []byte {
+	b = AppendTag(b, num, BytesType)
+	b = AppendVarint(b, uint64(len(id)))
+	b, _ = id.AppendBinary(b)
+
+	return b
+}
+
+// AppendString appends id as a string field numbered num.
+func AppendString(b []byte, num int32, id rid.ID) []byte {
+	b = AppendTag(b, num, BytesType)
+	b = AppendVarint(b, encodedLen)
+
+	return append(b, id.String()...)
+}
+
+// ConsumeTag parses a field tag from b, returning the field number, wire
+// type and the number of bytes read.
+func ConsumeTag(b []byte) (int32, Type, int, error) {
+	v, n, err := ConsumeVarint(b)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	num := v >> 3
+	if num < 1 || num > maxField {
+		return 0, 0, 0, fmt.Errorf("%w: field number %d", ErrMalformed, num)
+	}
+
+	return int32(num), Type(v & 7), n, nil
+}
+
+// ConsumeID parses the length-prefixed value of a bytes or string field
+// following its tag, returning the ID and the number of bytes read. Both the
+// binary and text forms are accepted.
+func ConsumeID(b []byte) (rid.ID, int, error) {
+	v, n, err := consumeBytes(b)
+	if err != nil {
+		return rid.NilID(), 0, err
+	}
+	id, err := decodeValue(v)
+	if err != nil {
+		return rid.NilID(), 0, err
+	}
+
+	return id, n, nil
+}
+
+// AppendVarint appends v to b as a base 128 varint.
+func AppendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(b, byte(v))
+}
+
+// ConsumeVarint parses a base 128 varint from b, returning its value and the
+// number of bytes read.
+func ConsumeVarint(b []byte) (uint64, int, error) {
+	var v uint64
+	for i := 0; i < len(b) && i < 10; i++ {
+		v |= uint64(b[i]&0x7f) << (7 * i)
+		if b[i] < 0x80 {
+			if i == 9 && b[i] > 1 {
+				break // overflows 64 bits
+			}
+			return v, i + 1, nil
+		}
+	}
+
+	return 0, 0, ErrMalformed
+}
+
+// consumeBytes parses a length-prefixed value from b.
+func consumeBytes(b []byte) ([]byte, int, error) {
+	l, n, err := ConsumeVarint(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	if l > uint64(len(b)-n) {
+		return nil, 0, ErrMalformed
+	}
+
+	return b[n : n+int(l)], n + int(l), nil
+}
+
+// skipValue returns the length of a value of type typ at the start of b.
+func skipValue(b []byte, typ Type) (int, error) {
+	switch typ {
+	case VarintType:
+		_, n, err := ConsumeVarint(b)
+		return n, err
+	case Fixed64Type:
+		if len(b) < 8 {
+			return 0, ErrMalformed
+		}
+		return 8, nil
+	case Fixed32Type:
+		if len(b) < 4 {
+			return 0, ErrMalformed
+		}
+		return 4, nil
+	case BytesType:
+		_, n, err := consumeBytes(b)
+		return n, err
+	default:
+		return 0, fmt.Errorf("%w: unsupported wire type %d", ErrMalformed, typ)
+	}
+}
+
+// decodeValue returns the ID in a field payload of either form.
+func decodeValue(v []byte) (rid.ID, error) {
+	if len(v) == encodedLen {
+		return rid.FromString(string(v))
+	}
+
+	return FromBytes(v)
+}
+
+// Message is the well-known message shape rid.v1.ID, described by Schema.
+type Message struct {
+	ID rid.ID
+}
+
+// Marshal returns the wire encoding of m; a nil ID encodes as an empty
+// message.
+func (m Message) Marshal() ([]byte, error) {
+	return m.AppendMarshal(nil)
+}
+
+// AppendMarshal appends the wire encoding of m to b.
+func (m Message) AppendMarshal(b []byte) ([]byte, error) {
+	if m.ID.IsNil() {
+		return b, nil
+	}
+
+	return AppendBytes(b, 1, m.ID), nil
+}
+
+// Unmarshal parses the wire encoding of a rid.v1.ID message into m,
+// skipping unknown fields. Where field 1 repeats, the last wins.
+func (m *Message) Unmarshal(b []byte) error {
+	m.ID = rid.NilID()
+	for len(b) > 0 {
+		num, typ, n, err := ConsumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		if num == 1 {
+			if typ != BytesType {
+				return fmt.Errorf("%w: field 1 has wire type %d", ErrMalformed, typ)
+			}
+			id, n, err := ConsumeID(b)
+			if err != nil {
+				return err
+			}
+			m.ID, b = id, b[n:]
+			continue
+		}
+		n, err = skipValue(b, typ)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+
+	return nil
+}
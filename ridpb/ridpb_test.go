@@ -0,0 +1,153 @@
+package ridpb
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+// dfp7emzzzzy30ey2
+var testID = rid.ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+
+func TestFieldConversions(t *testing.T) {
+	if got := ToBytes(testID); !bytes.Equal(got, testID[:]) {
+		t.Errorf("ToBytes() = %v", got)
+	}
+	if got := ToBytes(rid.NilID()); got != nil {
+		t.Errorf("ToBytes(nil) = %v, want nil", got)
+	}
+	if got, err := FromBytes(testID[:]); err != nil || got != testID {
+		t.Errorf("FromBytes() = %v, %v", got, err)
+	}
+	if got, err := FromBytes(nil); err != nil || !got.IsNil() {
+		t.Errorf("FromBytes(nil) = %v, %v", got, err)
+	}
+	if _, err := FromBytes([]byte{1, 2, 3}); err != rid.ErrInvalidID {
+		t.Errorf("FromBytes(short) err = %v", err)
+	}
+	if got := ToString(testID); got != "dfp7emzzzzy30ey2" {
+		t.Errorf("ToString() = %v", got)
+	}
+	if got := ToString(rid.NilID()); got != "" {
+		t.Errorf("ToString(nil) = %q", got)
+	}
+	if got, err := FromString("dfp7emzzzzy30ey2"); err != nil || got != testID {
+		t.Errorf("FromString() = %v, %v", got, err)
+	}
+	if got, err := FromString(""); err != nil || !got.IsNil() {
+		t.Errorf("FromString(\"\") = %v, %v", got, err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	b, err := Message{ID: testID}.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tag 0x0a is field 1, wire type 2; then a length of 10
+	want := append([]byte{0x0a, 0x0a}, testID[:]...)
+	if !bytes.Equal(b, want) {
+		t.Errorf("Marshal() = % x, want % x", b, want)
+	}
+	var m Message
+	if err := m.Unmarshal(b); err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != testID {
+		t.Errorf("Unmarshal() = %v, want %v", m.ID, testID)
+	}
+	if b, _ := (Message{}).Marshal(); len(b) != 0 {
+		t.Errorf("Marshal(nil ID) = % x, want empty", b)
+	}
+	if err := m.Unmarshal(nil); err != nil || !m.ID.IsNil() {
+		t.Errorf("Unmarshal(empty) = %v, %v", m.ID, err)
+	}
+}
+
+func TestMessageUnknownFields(t *testing.T) {
+	var b []byte
+	b = AppendTag(b, 2, VarintType)
+	b = AppendVarint(b, math.MaxUint64)
+	b = AppendTag(b, 3, Fixed64Type)
+	b = append(b, make([]byte, 8)...)
+	b = AppendString(b, 1, testID) // text form accepted
+	b = AppendTag(b, 4, Fixed32Type)
+	b = append(b, make([]byte, 4)...)
+	b = AppendTag(b, 5, BytesType)
+	b = AppendVarint(b, 3)
+	b = append(b, "abc"...)
+	var m Message
+	if err := m.Unmarshal(b); err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != testID {
+		t.Errorf("Unmarshal() = %v, want %v", m.ID, testID)
+	}
+}
+
+func TestMessageMalformed(t *testing.T) {
+	valid, _ := Message{ID: testID}.Marshal()
+	for name, b := range map[string][]byte{
+		"truncated":       valid[:len(valid)-1],
+		"bad length":      {0x0a, 0x03, 1, 2, 3},
+		"bad text":        append([]byte{0x0a, 0x10}, "dfp7emzzzzy30eyu"...),
+		"field 1 varint":  {0x08, 0x01},
+		"field 0":         {0x02, 0x00},
+		"group":           {0x13},
+		"long varint":     {0x10, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02},
+		"truncated fixed": {0x19, 0x00},
+	} {
+		var m Message
+		if err := m.Unmarshal(b); err == nil {
+			t.Errorf("%s: Unmarshal(% x) want error", name, b)
+		}
+	}
+}
+
+func TestConsumeID(t *testing.T) {
+	b := AppendBytes(nil, 7, testID)
+	num, typ, n, err := ConsumeTag(b)
+	if err != nil || num != 7 || typ != BytesType {
+		t.Fatalf("ConsumeTag() = %d, %d, %d, %v", num, typ, n, err)
+	}
+	id, m, err := ConsumeID(b[n:])
+	if err != nil || id != testID || n+m != len(b) {
+		t.Errorf("ConsumeID() = %v, %d, %v", id, m, err)
+	}
+	if _, _, err := ConsumeID([]byte{0x0a, 1}); !errors.Is(err, ErrMalformed) {
+		t.Errorf("ConsumeID(truncated) err = %v, want %v", err, ErrMalformed)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		b     []byte
+		valid bool
+	}{
+		{nil, true},
+		{testID[:], true},
+		{[]byte("dfp7emzzzzy30ey2"), true},
+		{[]byte("DFP7EMZZZZY30EY2"), false},
+		{testID[:9], false},
+	} {
+		if err := Validate(tc.b); (err == nil) != tc.valid {
+			t.Errorf("Validate(%q) = %v, want valid %v", tc.b, err, tc.valid)
+		}
+	}
+}
+
+func TestVarint(t *testing.T) {
+	for _, v := range []uint64{0, 1, 127, 128, 300, 1<<35 + 7, math.MaxUint64} {
+		b := AppendVarint(nil, v)
+		got, n, err := ConsumeVarint(b)
+		if err != nil || got != v || n != len(b) {
+			t.Errorf("ConsumeVarint(AppendVarint(%d)) = %d, %d, %v", v, got, n, err)
+		}
+	}
+	if got := AppendVarint(nil, 300); !bytes.Equal(got, []byte{0xac, 0x02}) {
+		t.Errorf("AppendVarint(300) = % x", got)
+	}
+}
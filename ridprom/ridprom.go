@@ -0,0 +1,79 @@
+/*
+Package ridprom exposes the counters of rid Generators in the Prometheus text
+exposition format without depending on the Prometheus client library. Each
+generator is labelled with its name:
+
+	http.Handle("/metrics", ridprom.Handler(map[string]*rid.Generator{"orders": g}))
+
+The metrics are those of rid.Stats, plus rid.Generator.Rate as a gauge.
+*/
+package ridprom
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mwyvr/rid"
+)
+
+// ContentType is the media type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type metric struct {
+	name, typ, help string
+	value           func(s rid.Stats, g *rid.Generator) uint64
+}
+
+var metrics = []metric{
+	{"rid_ids_issued_total", "counter", "IDs issued.",
+		func(s rid.Stats, _ *rid.Generator) uint64 { return s.Issued }},
+	{"rid_ids_per_second", "gauge", "IDs issued during the last complete second.",
+		func(_ rid.Stats, g *rid.Generator) uint64 { return g.Rate() }},
+	{"rid_clock_regressions_total", "counter", "Times the clock was found behind the last issued timestamp.",
+		func(s rid.Stats, _ *rid.Generator) uint64 { return s.ClockRegressions }},
+	{"rid_duplicates_total", "counter", "Collisions caught and regenerated by the duplicate guard.",
+		func(s rid.Stats, _ *rid.Generator) uint64 { return s.Duplicates }},
+}
+
+// WriteText writes the counters of gens, keyed by name, to w.
+func WriteText(w io.Writer, gens map[string]*rid.Generator) error {
+	names := make([]string, 0, len(gens))
+	for name := range gens {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	stats := make([]rid.Stats, len(names))
+	for i, name := range names {
+		stats[i] = gens[name].Stats()
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.typ)
+		for i, name := range names {
+			fmt.Fprintf(bw, "%s{generator=%s} %d\n", m.name, quote(name),
+				m.value(stats[i], gens[name]))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Handler returns an http.Handler serving the counters of gens.
+func Handler(gens map[string]*rid.Generator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		WriteText(w, gens)
+	})
+}
+
+// labelEscaper escapes a label value as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quote(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
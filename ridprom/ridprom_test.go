@@ -0,0 +1,58 @@
+package ridprom
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mwyvr/rid"
+)
+
+func TestWriteText(t *testing.T) {
+	a, err := rid.NewGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := rid.NewGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if _, err := a.New(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var sb strings.Builder
+	if err := WriteText(&sb, map[string]*rid.Generator{"orders": a, `we"ird`: b}); err != nil {
+		t.Fatal(err)
+	}
+	got := sb.String()
+	for _, want := range []string{
+		"# HELP rid_ids_issued_total IDs issued.\n# TYPE rid_ids_issued_total counter\n" +
+			"rid_ids_issued_total{generator=\"orders\"} 3\n" +
+			"rid_ids_issued_total{generator=\"we\\\"ird\"} 0\n",
+		"# TYPE rid_ids_per_second gauge\n",
+		"rid_clock_regressions_total{generator=\"orders\"} 0\n",
+		"rid_duplicates_total{generator=\"orders\"} 0\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	g, err := rid.NewGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	Handler(map[string]*rid.Generator{"g": g}).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ContentType)
+	}
+	if !strings.Contains(rec.Body.String(), `rid_ids_issued_total{generator="g"} 0`) {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
@@ -0,0 +1,94 @@
+package rid
+
+import (
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	s, err := NewSigner(10, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := IDs[0].id
+	signed := s.Sign(id)
+	// tag cross-checked with Python's hmac and base64 modules
+	if got, want := signed, "dfp7emzzzzy30ey2wbryxjn69xq87cwm"; got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+	got, err := s.Verify(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("Verify() = %v, want %v", got, id)
+	}
+}
+
+func TestSignerVerifyInvalid(t *testing.T) {
+	s, _ := NewSigner(10, []byte("secret"))
+	signed := s.Sign(IDs[0].id)
+	flip := func(s string, i int) string {
+		b := []byte(s)
+		if b[i] == '0' {
+			b[i] = '1'
+		} else {
+			b[i] = '0'
+		}
+		return string(b)
+	}
+	other, _ := NewSigner(10, []byte("other"))
+	for name, str := range map[string]string{
+		"empty":       "",
+		"id only":     signed[:encodedLen],
+		"truncated":   signed[:len(signed)-1],
+		"extended":    signed + "0",
+		"tampered id": flip(signed, 3),
+		"tampered":    flip(signed, len(signed)-1),
+		"invalid id":  "u" + signed[1:],
+		"wrong key":   other.Sign(IDs[0].id),
+	} {
+		if id, err := s.Verify(str); err != ErrInvalidSignature || !id.IsNil() {
+			t.Errorf("%s: Verify(%q) = %v, %v, want %v", name, str, id, err, ErrInvalidSignature)
+		}
+	}
+}
+
+func TestSignerKeyRotation(t *testing.T) {
+	old, _ := NewSigner(8, []byte("old"))
+	rotated, err := NewSigner(8, []byte("new"), []byte("old"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	retired, _ := NewSigner(8, []byte("new"))
+	id := New()
+	for name, tc := range map[string]struct {
+		signer *Signer
+		signed string
+		valid  bool
+	}{
+		"old token, rotated signer": {rotated, old.Sign(id), true},
+		"new token, rotated signer": {rotated, rotated.Sign(id), true},
+		"new token, old signer":     {old, rotated.Sign(id), false},
+		"old token, key retired":    {retired, old.Sign(id), false},
+		"new token, key retired":    {retired, rotated.Sign(id), true},
+	} {
+		got, err := tc.signer.Verify(tc.signed)
+		if valid := err == nil && got == id; valid != tc.valid {
+			t.Errorf("%s: Verify() = %v, %v, want valid %v", name, got, err, tc.valid)
+		}
+	}
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	for _, n := range []int{3, 33} {
+		if _, err := NewSigner(n, []byte("k")); err == nil {
+			t.Errorf("NewSigner(%d) want error", n)
+		}
+	}
+	if _, err := NewSigner(8); err == nil {
+		t.Error("NewSigner(no keys) want error")
+	}
+	if _, err := NewSigner(8, []byte{}); err == nil {
+		t.Error("NewSigner(empty key) want error")
+	}
+}
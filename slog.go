@@ -0,0 +1,49 @@
+package rid
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, logging id in its Base32 form. Like
+// String, it allocates the encoded string. A nil ID resolves to an empty
+// group, which handlers omit.
+func (id ID) LogValue() slog.Value {
+	if id.IsNil() {
+		return slog.GroupValue()
+	}
+	return slog.StringValue(id.String())
+}
+
+// LogValue implements slog.LogValuer; see ID.LogValue.
+func (id MilliID) LogValue() slog.Value {
+	if id.IsNil() {
+		return slog.GroupValue()
+	}
+	return slog.StringValue(id.String())
+}
+
+// Attr returns an slog.Attr for id, omitted if id is nil. Its value is the
+// boxed id, resolved by ID.LogValue when a handler formats it.
+func Attr(key string, id ID) slog.Attr {
+	return slog.Any(key, id)
+}
+
+// GroupAttr returns an slog.Attr grouping id with its decoded timestamp, time
+// and random value, for debug logging. The group is omitted if id is nil.
+func GroupAttr(key string, id ID) slog.Attr {
+	return slog.Any(key, groupValuer(id))
+}
+
+// groupValuer defers building the group of GroupAttr until it is logged.
+type groupValuer ID
+
+func (v groupValuer) LogValue() slog.Value {
+	id := ID(v)
+	if id.IsNil() {
+		return slog.GroupValue()
+	}
+	return slog.GroupValue(
+		slog.String("id", id.String()),
+		slog.Int64("ts", id.Timestamp()),
+		slog.Time("time", id.Time()),
+		slog.Uint64("random", id.Random()),
+	)
+}
@@ -0,0 +1,46 @@
+package rid
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	id := ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+	mid := MilliID{0x01, 0x95, 0x20, 0x16, 0xda, 0x40, 0x00, 0x00, 0x00, 0x01}
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want string
+	}{
+		{"attr", Attr("id", id), "id=dfp7emzzzzy30ey2"},
+		{"attr nil", Attr("id", nilID), ""},
+		{"any", slog.Any("id", id), "id=dfp7emzzzzy30ey2"},
+		{"milli", slog.Any("id", mid), "id=" + mid.String()},
+		{"milli nil", slog.Any("id", MilliID{}), ""},
+		{"group", GroupAttr("req", id),
+			"req.id=dfp7emzzzzy30ey2 req.ts=1672246995 req.time=2022-12-28T17:03:15.000Z req.random=281474912761794"},
+		{"group nil", GroupAttr("req", nilID), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					switch {
+					case len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey):
+						return slog.Attr{}
+					case a.Value.Kind() == slog.KindTime:
+						return slog.Time(a.Key, a.Value.Time().UTC())
+					}
+					return a
+				},
+			}))
+			logger.Info("", tt.attr)
+			if got := string(bytes.TrimSpace(buf.Bytes())); got != tt.want {
+				t.Errorf("logged %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package rid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// ErrStateLocked is returned by NewGenerator when another process holds the
+// lock on the state file.
+var ErrStateLocked = errors.New("rid: state file locked by another process")
+
+// stateFile persists the highest timestamp issued by a Generator so that IDs
+// never go backwards across process restarts.
+type stateFile struct {
+	f *os.File
+}
+
+// stateLen is the fixed width of a stored timestamp plus newline; a fixed
+// width lets each store overwrite the last in place.
+const stateLen = 21
+
+// openState opens or creates the state file at path, takes an exclusive lock
+// on it and returns the stored timestamp, zero if the file is new.
+func openState(path string) (*stateFile, int64, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, 0, fmt.Errorf("rid: opening state file: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	b, err := io.ReadAll(io.LimitReader(f, stateLen))
+	if err != nil {
+		f.Close()
+		return nil, 0, fmt.Errorf("rid: reading state file: %w", err)
+	}
+	var last int64
+	if b = bytes.TrimSpace(b); len(b) > 0 {
+		last, err = strconv.ParseInt(string(b), 10, 64)
+		if err != nil {
+			f.Close()
+			return nil, 0, fmt.Errorf("rid: corrupt state file %s: %w", path, err)
+		}
+	}
+
+	return &stateFile{f: f}, last, nil
+}
+
+// store durably records s as the highest issued timestamp.
+func (st *stateFile) store(s int64) error {
+	if _, err := st.f.WriteAt([]byte(fmt.Sprintf("%020d\n", s)), 0); err != nil {
+		return fmt.Errorf("rid: writing state file: %w", err)
+	}
+	if err := st.f.Sync(); err != nil {
+		return fmt.Errorf("rid: syncing state file: %w", err)
+	}
+
+	return nil
+}
+
+// close releases the lock and closes the state file.
+func (st *stateFile) close() error {
+	return st.f.Close()
+}
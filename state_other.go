@@ -0,0 +1,14 @@
+//go:build !unix
+
+package rid
+
+import (
+	"errors"
+	"os"
+)
+
+// lockFile is not implemented on this platform; a state file that cannot be
+// locked cannot guarantee monotonicity, so it is refused.
+func lockFile(f *os.File) error {
+	return errors.New("rid: state file locking not supported on this platform")
+}
@@ -0,0 +1,92 @@
+//go:build unix
+
+package rid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGeneratorStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rid.state")
+	start := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{t: start}
+
+	g, err := NewGenerator(WithClock(clock.Now), WithStateFile(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New(); err != nil {
+		t.Fatal(err)
+	}
+	// a second generator can't take the lock while the first holds it
+	if _, err := NewGenerator(WithStateFile(path)); !errors.Is(err, ErrStateLocked) {
+		t.Errorf("NewGenerator() err = %v, want %v", err, ErrStateLocked)
+	}
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// restart after the clock stepped back an hour
+	clock.t = start.Add(-time.Hour)
+	g, err = NewGenerator(WithClock(clock.Now), WithStateFile(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	id, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := id.Time(); !got.Equal(start) {
+		t.Errorf("New() after restart time = %v, want %v", got.UTC(), start)
+	}
+	if got := g.Stats().ClockRegressions; got != 1 {
+		t.Errorf("Stats().ClockRegressions = %d, want 1", got)
+	}
+
+	// the clock recovers and the newer second is persisted
+	clock.t = start.Add(time.Minute)
+	if _, err := g.New(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "00000000001717243260\n"; got != want {
+		t.Errorf("state file = %q, want %q", got, want)
+	}
+}
+
+func TestGeneratorStateFileClockError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rid.state")
+	if err := os.WriteFile(path, []byte("1717243200\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	clock := &fakeClock{t: time.Unix(1717243200-1, 0)}
+	g, err := NewGenerator(WithClock(clock.Now), WithStateFile(path), WithClockPolicy(ClockError))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+	if _, err := g.New(); !errors.Is(err, ErrClockRegression) {
+		t.Errorf("New() err = %v, want %v", err, ErrClockRegression)
+	}
+}
+
+func TestGeneratorStateFileCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rid.state")
+	if err := os.WriteFile(path, []byte("not a timestamp"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGenerator(WithStateFile(path)); err == nil {
+		t.Error("NewGenerator() with corrupt state file want error")
+	}
+	if _, err := NewGenerator(WithStateFile("")); err == nil {
+		t.Error("WithStateFile(\"\") want error")
+	}
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive, non-blocking advisory lock on f, released
+// when f is closed.
+func lockFile(f *os.File) error {
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return ErrStateLocked
+		}
+		return fmt.Errorf("rid: locking state file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package rid
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+const uuidStringLen = 36 // hyphenated
+
+// UUID packs id into an RFC 9562 version 8 UUID, for systems requiring UUID
+// columns. The layout keeps the bytes of id in order so that UUIDs sort as
+// their IDs do:
+//
+//	bytes 0-5   id[0:6]: timestamp and first 2 random bytes
+//	byte  6     0x80: version 8
+//	byte  7     id[6]
+//	byte  8     0x80: RFC 9562 variant
+//	bytes 9-11  id[7:10]
+//	bytes 12-15 zero
+//
+// The conversion is lossless; FromUUID reverses it.
+func (id ID) UUID() [16]byte {
+	var u [16]byte
+	copy(u[0:6], id[0:6])
+	u[6] = 0x80
+	u[7] = id[6]
+	u[8] = 0x80
+	copy(u[9:12], id[7:10])
+
+	return u
+}
+
+// FromUUID returns the ID packed into u by ID.UUID. UUIDs not of that form,
+// including other version 8 UUIDs, return ErrInvalidID.
+func FromUUID(u [16]byte) (ID, error) {
+	if u[6] != 0x80 || u[8] != 0x80 || u[12]|u[13]|u[14]|u[15] != 0 {
+		return nilID, ErrInvalidID
+	}
+	var id ID
+	copy(id[0:6], u[0:6])
+	id[6] = u[7]
+	copy(id[7:10], u[9:12])
+
+	return id, nil
+}
+
+// UUIDString returns the UUID form of id in canonical hyphenated form, such
+// as 63ac76d3-ffff-80fc-8030-37c200000000.
+func (id ID) UUIDString() string {
+	return string(appendUUID(make([]byte, 0, uuidStringLen), id.UUID()))
+}
+
+// FromUUIDString parses a UUID in canonical hyphenated form, in either case,
+// returning the ID packed into it by ID.UUID.
+func FromUUIDString(str string) (ID, error) {
+	u, err := parseUUID([]byte(str))
+	if err != nil {
+		return nilID, err
+	}
+
+	return FromUUID(u)
+}
+
+// appendUUID appends u in canonical hyphenated form to dst.
+func appendUUID(dst []byte, u [16]byte) []byte {
+	dst = hex.AppendEncode(dst, u[0:4])
+	dst = append(dst, '-')
+	dst = hex.AppendEncode(dst, u[4:6])
+	dst = append(dst, '-')
+	dst = hex.AppendEncode(dst, u[6:8])
+	dst = append(dst, '-')
+	dst = hex.AppendEncode(dst, u[8:10])
+	dst = append(dst, '-')
+
+	return hex.AppendEncode(dst, u[10:16])
+}
+
+// parseUUID parses a UUID in canonical hyphenated form.
+func parseUUID(text []byte) ([16]byte, error) {
+	var u [16]byte
+	if len(text) != uuidStringLen ||
+		text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return u, ErrInvalidID
+	}
+	src := make([]byte, 0, 32)
+	src = append(src, text[0:8]...)
+	src = append(src, text[9:13]...)
+	src = append(src, text[14:18]...)
+	src = append(src, text[19:23]...)
+	src = append(src, text[24:36]...)
+	if _, err := hex.Decode(u[:], src); err != nil {
+		return u, ErrInvalidID
+	}
+
+	return u, nil
+}
+
+// AsUUID wraps an ID so that it is stored in SQL and serialized in JSON and
+// text in its UUID form, for example in a Postgres uuid column:
+//
+//	db.Exec("INSERT INTO t (id) VALUES ($1)", rid.AsUUID(id))
+//	var u rid.AsUUID
+//	row.Scan(&u)
+//	id := rid.ID(u)
+type AsUUID ID
+
+// String returns the canonical hyphenated UUID form.
+func (u AsUUID) String() string {
+	return ID(u).UUIDString()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u AsUUID) MarshalText() ([]byte, error) {
+	return appendUUID(make([]byte, 0, uuidStringLen), ID(u).UUID()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *AsUUID) UnmarshalText(text []byte) error {
+	b, err := parseUUID(text)
+	if err != nil {
+		*u = AsUUID(nilID)
+		return err
+	}
+	id, err := FromUUID(b)
+	*u = AsUUID(id)
+
+	return err
+}
+
+// MarshalJSON implements the json.Marshaler interface; a nil ID is null.
+func (u AsUUID) MarshalJSON() ([]byte, error) {
+	if ID(u).IsNil() {
+		return []byte("null"), nil
+	}
+	text := make([]byte, 0, uuidStringLen+2)
+	text = append(text, '"')
+	text = appendUUID(text, ID(u).UUID())
+
+	return append(text, '"'), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *AsUUID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*u = AsUUID(nilID)
+		return nil
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return ErrInvalidID
+	}
+
+	return u.UnmarshalText(b[1 : len(b)-1])
+}
+
+// Value implements package sql's driver.Valuer; a nil ID is NULL.
+func (u AsUUID) Value() (driver.Value, error) {
+	if ID(u).IsNil() {
+		return nil, nil
+	}
+
+	return u.String(), nil
+}
+
+// Scan implements the sql.Scanner interface, accepting the canonical string
+// form or the 16 raw bytes some drivers return for UUID columns.
+func (u *AsUUID) Scan(value interface{}) error {
+	switch val := value.(type) {
+	case string:
+		return u.UnmarshalText([]byte(val))
+	case []byte:
+		if len(val) == 16 {
+			id, err := FromUUID([16]byte(val))
+			*u = AsUUID(id)
+			return err
+		}
+		return u.UnmarshalText(val)
+	case nil:
+		*u = AsUUID(nilID)
+		return nil
+	default:
+		return fmt.Errorf("rid: scanning unsupported type: %T", value)
+	}
+}
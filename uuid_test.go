@@ -0,0 +1,143 @@
+package rid
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestUUID(t *testing.T) {
+	// dfp7emzzzzy30ey2 ts:1672246995 rnd:281474912761794
+	id := ID{0x63, 0xac, 0x76, 0xd3, 0xff, 0xff, 0xfc, 0x30, 0x37, 0xc2}
+	u := id.UUID()
+	if got, want := u[6]>>4, byte(8); got != want {
+		t.Errorf("version = %d, want %d", got, want)
+	}
+	if got, want := u[8]>>6, byte(0b10); got != want {
+		t.Errorf("variant = %b, want %b", got, want)
+	}
+	if got, want := id.UUIDString(), "63ac76d3-ffff-80fc-8030-37c200000000"; got != want {
+		t.Errorf("UUIDString() = %s, want %s", got, want)
+	}
+	for _, s := range []string{
+		"63ac76d3-ffff-80fc-8030-37c200000000",
+		"63AC76D3-FFFF-80FC-8030-37C200000000",
+	} {
+		got, err := FromUUIDString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != id {
+			t.Errorf("FromUUIDString(%s) = %v, want %v", s, got, id)
+		}
+	}
+}
+
+func TestUUIDRoundTrip(t *testing.T) {
+	f := func(id ID) bool {
+		got, err := FromUUID(id.UUID())
+		if err != nil || got != id {
+			return false
+		}
+		got, err = FromUUIDString(id.UUIDString())
+		return err == nil && got == id
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUUIDOrder(t *testing.T) {
+	ids := append([]ID(nil), IDList...)
+	Sort(ids)
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.UUIDString()
+	}
+	if !sort.StringsAreSorted(strs) {
+		t.Errorf("UUID strings not in ID order: %v", strs)
+	}
+}
+
+func TestFromUUIDInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"63ac76d3ffff80fc803037c200000000",
+		"63ac76d3-ffff-80fc-8030-37c2000000",
+		"63ac76d3-ffff-80fc-8030-37c20000000g",
+		"63ac76d3_ffff_80fc_8030_37c200000000",
+		"01955ef9-df51-7d46-9d2b-f01195e3fa80", // version 7
+		"63ac76d3-ffff-80fc-c030-37c200000000", // wrong variant
+		"63ac76d3-ffff-80fc-8030-37c200000001", // not packed by rid
+	} {
+		if _, err := FromUUIDString(s); err != ErrInvalidID {
+			t.Errorf("FromUUIDString(%q) err = %v, want %v", s, err, ErrInvalidID)
+		}
+	}
+}
+
+func TestAsUUIDJSON(t *testing.T) {
+	type row struct {
+		ID  AsUUID
+		Ptr *AsUUID
+	}
+	id := AsUUID(IDs[0].id)
+	data, err := json.Marshal(row{ID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"ID":"63ac76d3-ffff-80fc-8030-37c200000000","Ptr":null}`; got != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+	var v row
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ID != id {
+		t.Errorf("json.Unmarshal() = %v, want %v", v.ID, id)
+	}
+	if got, _ := json.Marshal(AsUUID{}); string(got) != "null" {
+		t.Errorf("json.Marshal(nil) = %s, want null", got)
+	}
+	for _, bad := range []string{`{"ID":1}`, `{"ID":"dfp7emzzzzy30ey2"}`} {
+		if err := json.Unmarshal([]byte(bad), &v); err == nil {
+			t.Errorf("json.Unmarshal(%s) want error", bad)
+		}
+	}
+}
+
+func TestAsUUIDDriver(t *testing.T) {
+	id := AsUUID(New())
+	val, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := val.(string); !ok || len(s) != uuidStringLen {
+		t.Errorf("Value() = %v, want UUID string", val)
+	}
+	raw := ID(id).UUID()
+	for _, v := range []interface{}{val, []byte(val.(string)), raw[:], strings.ToUpper(val.(string))} {
+		var got AsUUID
+		if err := got.Scan(v); err != nil {
+			t.Fatalf("Scan(%v) err = %v", v, err)
+		}
+		if got != id {
+			t.Errorf("Scan(%v) = %v, want %v", v, got, id)
+		}
+	}
+	var got AsUUID
+	if err := got.Scan(nil); err != nil || !ID(got).IsNil() {
+		t.Errorf("Scan(nil) = %v, %v", got, err)
+	}
+	if val, _ := got.Value(); val != nil {
+		t.Errorf("Value() of nil = %v, want nil", val)
+	}
+	if err := got.Scan(42); err == nil {
+		t.Error("Scan(int) want error")
+	}
+	if err := got.Scan(make([]byte, 16)); err != ErrInvalidID {
+		t.Errorf("Scan(zero bytes) err = %v, want %v", err, ErrInvalidID)
+	}
+}
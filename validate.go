@@ -0,0 +1,123 @@
+package rid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrTimestampTooEarly is returned when an ID's timestamp precedes
+	// ValidateOptions.NotBefore.
+	ErrTimestampTooEarly = errors.New("rid: timestamp too early")
+
+	// ErrTimestampInFuture is returned when an ID's timestamp is further in
+	// the future than ValidateOptions.MaxSkew allows.
+	ErrTimestampInFuture = errors.New("rid: timestamp in the future")
+
+	// ErrExpired is returned when an ID is older than ValidateOptions.MaxAge.
+	ErrExpired = errors.New("rid: id expired")
+)
+
+// freshSkew is the clock skew tolerated by ParseFresh; without a bound, a
+// forged future timestamp would extend a lifetime indefinitely.
+const freshSkew = time.Minute
+
+// ValidateOptions sets the bounds a plausible ID's timestamp must fall
+// within. Zero values disable the corresponding check.
+type ValidateOptions struct {
+	// NotBefore rejects IDs created before this time, for example before a
+	// service existed.
+	NotBefore time.Time
+
+	// MaxSkew rejects IDs created more than MaxSkew after Now, allowing for
+	// clock differences between the issuing and validating hosts.
+	MaxSkew time.Duration
+
+	// MaxAge rejects IDs created more than MaxAge before Now.
+	MaxAge time.Duration
+
+	// Now returns the current time; time.Now is used if nil.
+	Now func() time.Time
+}
+
+// Validate checks the timestamp of id against opts, returning a descriptive
+// error wrapping ErrTimestampTooEarly, ErrTimestampInFuture or ErrExpired if
+// it is implausible. The timestamp is decoded relative to the Unix epoch; see
+// Generator.Validate for IDs made with a custom epoch.
+func (id ID) Validate(opts ValidateOptions) error {
+	return opts.check(id.Time())
+}
+
+// ParseStrict decodes a Base32-encoded string as FromString does, then
+// validates the result against opts. Decoding failures return ErrInvalidID.
+func ParseStrict(str string, opts ValidateOptions) (ID, error) {
+	id, err := FromString(str)
+	if err != nil {
+		return nilID, err
+	}
+	if err := id.Validate(opts); err != nil {
+		return nilID, err
+	}
+
+	return id, nil
+}
+
+// ParseFresh decodes a Base32-encoded string, rejecting IDs older than maxAge
+// with ErrExpired and IDs dated more than a minute in the future with
+// ErrTimestampInFuture. It suits invite links and one-time codes. A maxAge
+// of zero or less fails closed: every ID is rejected with ErrExpired.
+func ParseFresh(str string, maxAge time.Duration) (ID, error) {
+	if maxAge <= 0 {
+		return nilID, fmt.Errorf("%w: max age %s is not positive", ErrExpired, maxAge)
+	}
+	return ParseStrict(str, ValidateOptions{MaxAge: maxAge, MaxSkew: freshSkew})
+}
+
+// Age returns the time elapsed between the creation of id and now. As IDs
+// have seconds resolution, age is measured from the start of the second in
+// which id was created and may exceed the true age by up to a second. The
+// age of an ID from the future is negative.
+func (id ID) Age(now time.Time) time.Duration {
+	return now.Sub(id.Time())
+}
+
+// ExpiredAfter reports whether id, given a lifetime of d, had expired at now.
+func (id ID) ExpiredAfter(d time.Duration, now time.Time) bool {
+	return id.Age(now) > d
+}
+
+// Validate checks the timestamp of id, decoded relative to the generator's
+// epoch, against opts.
+func (g *Generator) Validate(id ID, opts ValidateOptions) error {
+	return opts.check(g.Time(id))
+}
+
+// check returns an error if t falls outside the bounds set by o.
+func (o ValidateOptions) check(t time.Time) error {
+	if !o.NotBefore.IsZero() && t.Before(o.NotBefore.Truncate(time.Second)) {
+		return fmt.Errorf("%w: %s is before %s", ErrTimestampTooEarly,
+			t.UTC().Format(time.RFC3339), o.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if o.MaxSkew <= 0 && o.MaxAge <= 0 {
+		return nil
+	}
+	now := time.Now()
+	if o.Now != nil {
+		now = o.Now()
+	}
+	if o.MaxSkew > 0 {
+		if limit := now.Add(o.MaxSkew); t.After(limit) {
+			return fmt.Errorf("%w: %s is after %s", ErrTimestampInFuture,
+				t.UTC().Format(time.RFC3339), limit.UTC().Format(time.RFC3339))
+		}
+	}
+	if o.MaxAge > 0 {
+		if age := now.Sub(t); age > o.MaxAge {
+			return fmt.Errorf("%w: created %s ago, limit %s", ErrExpired,
+				age.Truncate(time.Second), o.MaxAge)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package rid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	opts := ValidateOptions{
+		NotBefore: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+		MaxSkew:   time.Minute,
+		Now:       func() time.Time { return now },
+	}
+	tests := []struct {
+		name string
+		t    time.Time
+		want error
+	}{
+		{"now", now, nil},
+		{"not before", opts.NotBefore, nil},
+		{"within skew", now.Add(time.Minute), nil},
+		{"too early", opts.NotBefore.Add(-time.Second), ErrTimestampTooEarly},
+		{"unix epoch", time.Unix(0, 0), ErrTimestampTooEarly},
+		{"future", now.Add(time.Minute + time.Second), ErrTimestampInFuture},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := NewWithTime(tt.t)
+			if err := id.Validate(opts); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() err = %v, want %v", err, tt.want)
+			}
+			got, err := ParseStrict(id.String(), opts)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ParseStrict() err = %v, want %v", err, tt.want)
+			}
+			if err == nil && got != id {
+				t.Errorf("ParseStrict() = %v, want %v", got, id)
+			}
+			if err != nil && !got.IsNil() {
+				t.Errorf("ParseStrict() = %v, want nil ID", got)
+			}
+		})
+	}
+}
+
+func TestValidateZeroOptions(t *testing.T) {
+	// zero options disable all checks
+	for _, v := range IDs {
+		if err := v.id.Validate(ValidateOptions{}); err != nil {
+			t.Errorf("Validate(%s) err = %v, want nil", v.encoded, err)
+		}
+	}
+}
+
+func TestParseStrictInvalid(t *testing.T) {
+	if _, err := ParseStrict("dfp7emzzzzy30eyu", ValidateOptions{}); err != ErrInvalidID {
+		t.Errorf("ParseStrict(invalid) err = %v, want %v", err, ErrInvalidID)
+	}
+}
+
+func TestGeneratorValidate(t *testing.T) {
+	epoch := time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+	g, err := NewGenerator(WithEpoch(epoch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := g.NewWithTime(epoch.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := ValidateOptions{NotBefore: epoch}
+	if err := g.Validate(id, opts); err != nil {
+		t.Errorf("Generator.Validate() err = %v, want nil", err)
+	}
+	// decoded relative to the Unix epoch, the ID appears to predate NotBefore
+	if err := id.Validate(opts); !errors.Is(err, ErrTimestampTooEarly) {
+		t.Errorf("ID.Validate() err = %v, want %v", err, ErrTimestampTooEarly)
+	}
+}
+
+func TestAge(t *testing.T) {
+	created := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	id := NewWithTime(created.Add(500 * time.Millisecond)) // truncated to the second
+	now := created.Add(time.Hour)
+	if got, want := id.Age(now), time.Hour; got != want {
+		t.Errorf("Age() = %v, want %v", got, want)
+	}
+	if got, want := id.Age(created.Add(-time.Second)), -time.Second; got != want {
+		t.Errorf("Age() of future ID = %v, want %v", got, want)
+	}
+	if id.ExpiredAfter(time.Hour, now) {
+		t.Error("ExpiredAfter(1h) at exactly 1h = true")
+	}
+	if !id.ExpiredAfter(time.Hour-time.Nanosecond, now) {
+		t.Error("ExpiredAfter(1h-1ns) at 1h = false")
+	}
+}
+
+func TestValidateMaxAge(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	opts := ValidateOptions{MaxAge: 24 * time.Hour, Now: func() time.Time { return now }}
+	if err := NewWithTime(now.Add(-24 * time.Hour)).Validate(opts); err != nil {
+		t.Errorf("Validate() at MaxAge err = %v, want nil", err)
+	}
+	err := NewWithTime(now.Add(-25 * time.Hour)).Validate(opts)
+	if !errors.Is(err, ErrExpired) {
+		t.Errorf("Validate() past MaxAge err = %v, want %v", err, ErrExpired)
+	}
+}
+
+func TestParseFresh(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		id   ID
+		want error
+	}{
+		{"fresh", NewWithTime(now), nil},
+		{"stale", NewWithTime(now.Add(-2 * time.Hour)), ErrExpired},
+		{"slightly ahead", NewWithTime(now.Add(30 * time.Second)), nil},
+		{"forged future", NewWithTime(now.Add(24 * time.Hour)), ErrTimestampInFuture},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFresh(tt.id.String(), time.Hour)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ParseFresh() err = %v, want %v", err, tt.want)
+			}
+			if err == nil && got != tt.id {
+				t.Errorf("ParseFresh() = %v, want %v", got, tt.id)
+			}
+		})
+	}
+	if _, err := ParseFresh("invalid", time.Hour); err != ErrInvalidID {
+		t.Errorf("ParseFresh(invalid) err = %v, want %v", err, ErrInvalidID)
+	}
+	// a zero or negative lifetime must not disable the expiry check
+	for _, maxAge := range []time.Duration{0, -time.Hour} {
+		got, err := ParseFresh(NewWithTime(now).String(), maxAge)
+		if !errors.Is(err, ErrExpired) || !got.IsNil() {
+			t.Errorf("ParseFresh(maxAge %v) = %v, %v, want nil ID, %v", maxAge, got, err, ErrExpired)
+		}
+	}
+}